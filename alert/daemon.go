@@ -21,6 +21,13 @@ type NetworkFeeChangeNotify struct {
 	OldNetworkFee *big.Int
 	NewNetworkFee *big.Int
 }
+type NetworkParamChangeNotify struct {
+	Block uint64
+	// Param is one of the store.NetworkParam params, OldValue is nil when unknown
+	Param    string
+	OldValue *big.Int
+	NewValue *big.Int
+}
 type OperatorFeeChangeNotify struct {
 	Block       uint64
 	OperatorId  uint64
@@ -31,12 +38,17 @@ type ValidatorProposeBlockNotify struct {
 	Slot      uint64
 	ClusterId string
 	Index     uint64
+	// Provisional is set for blocks seen on the event stream, which are not finalized yet
+	Provisional bool
 }
 type ValidatorMissedBlockNotify struct {
 	Epoch     uint64
 	Slot      uint64
 	ClusterId string
 	Index     uint64
+	// Reason is store.MissedNoBlock, store.MissedOrphaned or store.MissedUnknown
+	Reason      string
+	Provisional bool
 }
 type ValidatorBalanceDeltaNotify struct {
 	Epoch     uint64
@@ -48,6 +60,65 @@ type ValidatorSlashNotify struct {
 	ClusterId string
 	Index     []uint64
 }
+type FeeRecipientMismatchNotify struct {
+	Epoch             uint64
+	Slot              uint64
+	BlockNumber       uint64
+	ClusterId         string
+	Index             uint64
+	FeeRecipient      string
+	ExpectedRecipient string
+	// BlockValue in wei
+	BlockValue *big.Int
+}
+type ValidatorSlashingEvidenceNotify struct {
+	Epoch       uint64
+	ClusterId   string
+	OperatorIds string
+	Index       []uint64
+	// Slots of the blocks that included the slashing, one per Index
+	Slots []uint64
+	// SharedClusters are the other hit clusters that share SharedOperators with this cluster
+	SharedClusters  []string
+	SharedOperators []uint64
+}
+type ValidatorActivatedNotify struct {
+	Epoch     uint64
+	ClusterId string
+	Index     []uint64
+}
+type ValidatorOffline struct {
+	Index      uint64
+	StartEpoch uint64
+	// Epochs the validator has been offline for, PrevEpochs before this notify
+	Epochs     uint64
+	PrevEpochs uint64
+}
+type ValidatorOfflineNotify struct {
+	Epoch      uint64
+	ClusterId  string
+	Validators []ValidatorOffline
+}
+type ValidatorRecovered struct {
+	Index        uint64
+	StartEpoch   uint64
+	Epochs       uint64
+	MissedDuties uint64
+	Duration     time.Duration
+}
+type ValidatorRecoveredNotify struct {
+	Epoch      uint64
+	ClusterId  string
+	Validators []ValidatorRecovered
+}
+type ValidatorWithdrawnNotify struct {
+	ClusterId   string
+	Index       uint64
+	Slot        uint64
+	BlockNumber uint64
+	// Amount in gwei
+	Amount uint64
+}
 
 type AlarmDaemon struct {
 	cron *cron.Cron
@@ -56,12 +127,19 @@ type AlarmDaemon struct {
 	store    *store.Store
 	password string
 
-	networkFeeChangeChan      chan NetworkFeeChangeNotify
-	operatorFeeChangeChan     chan OperatorFeeChangeNotify
-	validatorProposeBlockChan chan ValidatorProposeBlockNotify
-	validatorMissedBlockChan  chan ValidatorMissedBlockNotify
-	validatorBalanceDeltaChan chan ValidatorBalanceDeltaNotify
-	validatorSlashNotifyChan  chan ValidatorSlashNotify
+	networkFeeChangeChan          chan NetworkFeeChangeNotify
+	networkParamChangeChan        chan NetworkParamChangeNotify
+	operatorFeeChangeChan         chan OperatorFeeChangeNotify
+	validatorProposeBlockChan     chan ValidatorProposeBlockNotify
+	validatorMissedBlockChan      chan ValidatorMissedBlockNotify
+	validatorBalanceDeltaChan     chan ValidatorBalanceDeltaNotify
+	validatorSlashNotifyChan      chan ValidatorSlashNotify
+	validatorSlashingEvidenceChan chan ValidatorSlashingEvidenceNotify
+	feeRecipientMismatchChan      chan FeeRecipientMismatchNotify
+	validatorActivatedChan        chan ValidatorActivatedNotify
+	validatorWithdrawnChan        chan ValidatorWithdrawnNotify
+	validatorOfflineChan          chan ValidatorOfflineNotify
+	validatorRecoveredChan        chan ValidatorRecoveredNotify
 
 	close chan struct{}
 }
@@ -70,17 +148,24 @@ type AlarmDaemon struct {
 func NewAlarmDaemon(store *store.Store, client *client.Eth1Client, password string) (*AlarmDaemon, error) {
 	c := cron.New()
 	alarm := &AlarmDaemon{
-		cron:                  c,
-		client:                client,
-		store:                 store,
-		password:              password,
-		networkFeeChangeChan:  make(chan NetworkFeeChangeNotify, 1),
-		operatorFeeChangeChan: make(chan OperatorFeeChangeNotify, 10),
-
-		validatorProposeBlockChan: make(chan ValidatorProposeBlockNotify, 1),
-		validatorMissedBlockChan:  make(chan ValidatorMissedBlockNotify, 1),
-		validatorBalanceDeltaChan: make(chan ValidatorBalanceDeltaNotify, 100),
-		validatorSlashNotifyChan:  make(chan ValidatorSlashNotify, 100),
+		cron:                   c,
+		client:                 client,
+		store:                  store,
+		password:               password,
+		networkFeeChangeChan:   make(chan NetworkFeeChangeNotify, 1),
+		networkParamChangeChan: make(chan NetworkParamChangeNotify, 10),
+		operatorFeeChangeChan:  make(chan OperatorFeeChangeNotify, 10),
+
+		validatorProposeBlockChan:     make(chan ValidatorProposeBlockNotify, 100),
+		validatorMissedBlockChan:      make(chan ValidatorMissedBlockNotify, 100),
+		validatorBalanceDeltaChan:     make(chan ValidatorBalanceDeltaNotify, 100),
+		validatorSlashNotifyChan:      make(chan ValidatorSlashNotify, 100),
+		validatorSlashingEvidenceChan: make(chan ValidatorSlashingEvidenceNotify, 100),
+		feeRecipientMismatchChan:      make(chan FeeRecipientMismatchNotify, 100),
+		validatorActivatedChan:        make(chan ValidatorActivatedNotify, 100),
+		validatorWithdrawnChan:        make(chan ValidatorWithdrawnNotify, 100),
+		validatorOfflineChan:          make(chan ValidatorOfflineNotify, 100),
+		validatorRecoveredChan:        make(chan ValidatorRecoveredNotify, 100),
 
 		close: make(chan struct{}),
 	}
@@ -98,6 +183,10 @@ func (d *AlarmDaemon) NetworkFeeChangeChan() chan<- NetworkFeeChangeNotify {
 	return d.networkFeeChangeChan
 }
 
+func (d *AlarmDaemon) NetworkParamChangeChan() chan<- NetworkParamChangeNotify {
+	return d.networkParamChangeChan
+}
+
 func (d *AlarmDaemon) OperatorFeeChangeChan() chan<- OperatorFeeChangeNotify {
 	return d.operatorFeeChangeChan
 }
@@ -118,6 +207,30 @@ func (d *AlarmDaemon) ValidatorSlashNotifyChan() chan<- ValidatorSlashNotify {
 	return d.validatorSlashNotifyChan
 }
 
+func (d *AlarmDaemon) ValidatorSlashingEvidenceChan() chan<- ValidatorSlashingEvidenceNotify {
+	return d.validatorSlashingEvidenceChan
+}
+
+func (d *AlarmDaemon) FeeRecipientMismatchChan() chan<- FeeRecipientMismatchNotify {
+	return d.feeRecipientMismatchChan
+}
+
+func (d *AlarmDaemon) ValidatorActivatedChan() chan<- ValidatorActivatedNotify {
+	return d.validatorActivatedChan
+}
+
+func (d *AlarmDaemon) ValidatorWithdrawnChan() chan<- ValidatorWithdrawnNotify {
+	return d.validatorWithdrawnChan
+}
+
+func (d *AlarmDaemon) ValidatorOfflineChan() chan<- ValidatorOfflineNotify {
+	return d.validatorOfflineChan
+}
+
+func (d *AlarmDaemon) ValidatorRecoveredChan() chan<- ValidatorRecoveredNotify {
+	return d.validatorRecoveredChan
+}
+
 func (d *AlarmDaemon) Start() {
 	_, err := d.cron.AddFunc("0 0 * * *", d.liquidationAlarm)
 	if err != nil {
@@ -190,6 +303,7 @@ func (d *AlarmDaemon) liquidationAlarm() {
 				liquidationMsgFormat := "MonitorSSV: Liquidation Warning!\n  Cluster: %s\n  Cluster Balance: %s ssv\n  Liquidation Block: %d\n  Operational Runway: %s"
 
 				msg := fmt.Sprintf(liquidationMsgFormat, clusterInfo.ClusterID, onChainBalanceStr, clusterInfo.LiquidationBlock, formatRunaway(clusterInfo.LiquidationBlock, curBlock))
+				msg += formatTopUp(&clusterInfo)
 				log.Infow("liquidationAlarm", "msg", msg)
 				err = alarm.Send(msg)
 				if err != nil {
@@ -239,9 +353,11 @@ func (d *AlarmDaemon) simulatedLiquidationAlarm() {
 				clusterInfo.UpcomingLiquidationBlock != clusterInfo.LiquidationBlock &&
 				curBlock+ac.ReportLiquidationThreshold >= clusterInfo.UpcomingLiquidationBlock {
 				onChainBalanceStr := store.CalcClusterOnChainBalance(curBlock, &clusterInfo)
-				liquidationMsgFormat := "MonitorSSV: Simulated Liquidation Warning!\n  Cluster: %s\n  Cluster Balance: %s ssv\n  Forecasted Liquidation Block: %d\n  Forecasted Operational Runway: %s"
+				// the forecast applies the declared operator fees and the scheduled network fees at their block
+				liquidationMsgFormat := "MonitorSSV: Simulated Liquidation Warning!\n  Cluster: %s\n  Cluster Balance: %s ssv\n  Forecasted Liquidation Block: %d\n  Forecasted Operational Runway: %s\n  Forecasted Burn Fee: %s ssv/year"
 
-				msg := fmt.Sprintf(liquidationMsgFormat, clusterInfo.ClusterID, onChainBalanceStr, clusterInfo.UpcomingLiquidationBlock, formatRunaway(clusterInfo.UpcomingLiquidationBlock, curBlock))
+				upcomingBurnFee := big.NewInt(0).Mul(big.NewInt(0).SetUint64(clusterInfo.UpcomingBurnFee), big.NewInt(2613400))
+				msg := fmt.Sprintf(liquidationMsgFormat, clusterInfo.ClusterID, onChainBalanceStr, clusterInfo.UpcomingLiquidationBlock, formatRunaway(clusterInfo.UpcomingLiquidationBlock, curBlock), utils.ToSSV(upcomingBurnFee, "%.2f"))
 				log.Infow("simulatedLiquidationAlarm", "msg", msg)
 				err = alarm.Send(msg)
 				if err != nil {
@@ -351,6 +467,7 @@ func (d *AlarmDaemon) weeklyReport() {
 			onChainBalanceStr := store.CalcClusterOnChainBalance(curBlock, &clusterInfo)
 			weeklyReportMsgFormat := "MonitorSSV: Weekly Report!\n  Cluster: %s\n  Validator Count: %d\n  Cluster Balance: %s ssv\n  Liquidation Block: %d\n  Operational Runway: %s"
 			msg := fmt.Sprintf(weeklyReportMsgFormat, clusterInfo.ClusterID, clusterInfo.ValidatorCount, onChainBalanceStr, clusterInfo.LiquidationBlock, formatRunaway(clusterInfo.LiquidationBlock, curBlock))
+			msg += formatTopUp(&clusterInfo)
 			log.Infow("weeklyReport", "msg", msg)
 			err = alarm.Send(msg)
 			if err != nil {
@@ -371,6 +488,12 @@ func (d *AlarmDaemon) alarmDaemonLoop() {
 				<-time.After(10 * time.Minute)
 				d.networkFeeChangeAlarm(networkFeeChange)
 			}()
+		case networkParamChange := <-d.networkParamChangeChan:
+			log.Infow("alarmDaemonLoop", "networkParamChange", networkParamChange)
+			go func() {
+				<-time.After(10 * time.Minute)
+				d.networkParamChangeAlarm(networkParamChange)
+			}()
 		case operatorFeeChange := <-d.operatorFeeChangeChan:
 			log.Infow("alarmDaemonLoop", "operatorFeeChange", operatorFeeChange)
 			go func() {
@@ -389,6 +512,24 @@ func (d *AlarmDaemon) alarmDaemonLoop() {
 		case validatorSlash := <-d.validatorSlashNotifyChan:
 			log.Infow("alarmDaemonLoop", "validatorSlashNotifyChan", validatorSlash)
 			d.validatorSlashAlarm(validatorSlash)
+		case validatorSlashingEvidence := <-d.validatorSlashingEvidenceChan:
+			log.Infow("alarmDaemonLoop", "validatorSlashingEvidenceChan", validatorSlashingEvidence)
+			d.validatorSlashingEvidenceAlarm(validatorSlashingEvidence)
+		case feeRecipientMismatch := <-d.feeRecipientMismatchChan:
+			log.Infow("alarmDaemonLoop", "feeRecipientMismatchChan", feeRecipientMismatch)
+			d.feeRecipientMismatchAlarm(feeRecipientMismatch)
+		case validatorActivated := <-d.validatorActivatedChan:
+			log.Infow("alarmDaemonLoop", "validatorActivatedChan", validatorActivated)
+			d.validatorActivatedAlarm(validatorActivated)
+		case validatorWithdrawn := <-d.validatorWithdrawnChan:
+			log.Infow("alarmDaemonLoop", "validatorWithdrawnChan", validatorWithdrawn)
+			d.validatorWithdrawnAlarm(validatorWithdrawn)
+		case validatorOffline := <-d.validatorOfflineChan:
+			log.Infow("alarmDaemonLoop", "validatorOfflineChan", validatorOffline)
+			d.validatorOfflineAlarm(validatorOffline)
+		case validatorRecovered := <-d.validatorRecoveredChan:
+			log.Infow("alarmDaemonLoop", "validatorRecoveredChan", validatorRecovered)
+			d.validatorRecoveredAlarm(validatorRecovered)
 		}
 	}
 }
@@ -514,6 +655,56 @@ func (d *AlarmDaemon) networkFeeChangeAlarm(networkFeeChange NetworkFeeChangeNot
 	}
 }
 
+// networkParamChangeAlarm reports DAO parameter changes to the owners that want network fee changes reported
+func (d *AlarmDaemon) networkParamChangeAlarm(networkParamChange NetworkParamChangeNotify) {
+	curBlock, err := d.client.BlockNumber()
+	if err != nil {
+		log.Warnw("networkParamChangeAlarm: BlockNumber", "err", err)
+		return
+	}
+
+	alarmConfigs, err := d.getAllAlarmInfos()
+	if err != nil {
+		log.Errorw("networkParamChangeAlarm: getAllAlarmInfos", "err", err)
+		return
+	}
+
+	oldValue := "unknown"
+	if networkParamChange.OldValue != nil {
+		oldValue = store.FormatNetworkParam(networkParamChange.Param, networkParamChange.OldValue.String())
+	}
+	newValue := store.FormatNetworkParam(networkParamChange.Param, networkParamChange.NewValue.String())
+
+	for eoaOwner, ac := range alarmConfigs {
+		if !ac.ReportNetworkFeeChange {
+			log.Infow("networkParamChangeAlarm: ReportNetworkFeeChange not set", "eoaOwner", eoaOwner)
+			continue
+		}
+		clusterInfos, err := d.store.GetAllClusterByEoaOwner(eoaOwner)
+		if err != nil {
+			log.Errorw("networkParamChangeAlarm: GetAllClusterByEoaOwner", "err", err)
+			continue
+		}
+
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("networkParamChangeAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			continue
+		}
+
+		for _, clusterInfo := range clusterInfos {
+			onChainBalanceStr := store.CalcClusterOnChainBalance(curBlock, &clusterInfo)
+			networkParamChangeMsgFormat := "MonitorSSV: Network Parameter Change Notice!\n  Parameter: %s\n  Old Value: %s\n  New Value: %s\n  Block: %d\n  Cluster: %s\n  Validator Count: %d\n  Cluster Balance: %s ssv\n  Liquidation Block: %d\n  Operational Runway: %s"
+			msg := fmt.Sprintf(networkParamChangeMsgFormat, networkParamChange.Param, oldValue, newValue, networkParamChange.Block, clusterInfo.ClusterID, clusterInfo.ValidatorCount, onChainBalanceStr, clusterInfo.LiquidationBlock, formatRunaway(clusterInfo.LiquidationBlock, curBlock))
+			log.Infow("networkParamChangeAlarm", "msg", msg)
+			err = alarm.Send(msg)
+			if err != nil {
+				log.Warnw("networkParamChangeAlarm: Send", "msg", msg, "err", err)
+			}
+		}
+	}
+}
+
 func (d *AlarmDaemon) proposeBlockAlarm(validatorProposeBlock ValidatorProposeBlockNotify) {
 	ac, err := d.getClusterAlarmInfo(validatorProposeBlock.ClusterId)
 	if err != nil {
@@ -533,8 +724,8 @@ func (d *AlarmDaemon) proposeBlockAlarm(validatorProposeBlock ValidatorProposeBl
 			return
 		}
 
-		reportProposeBlockMsgFormat := "MonitorSSV: Validator propose block!\n  Cluster ID: %s\n  Validator Index: %d\n  Epoch: %d\n  Slot: %d\n"
-		msg := fmt.Sprintf(reportProposeBlockMsgFormat, validatorProposeBlock.ClusterId, validatorProposeBlock.Index, validatorProposeBlock.Epoch, validatorProposeBlock.Slot)
+		reportProposeBlockMsgFormat := "MonitorSSV: Validator propose block!\n  Cluster ID: %s\n  Validator Index: %d\n  Epoch: %d\n  Slot: %d\n  Status: %s\n"
+		msg := fmt.Sprintf(reportProposeBlockMsgFormat, validatorProposeBlock.ClusterId, validatorProposeBlock.Index, validatorProposeBlock.Epoch, validatorProposeBlock.Slot, blockStatus(validatorProposeBlock.Provisional))
 		log.Infow("proposeBlockAlarm", "msg", msg)
 		err = alarm.Send(msg)
 		if err != nil {
@@ -562,8 +753,8 @@ func (d *AlarmDaemon) missedBlockAlarm(validatorMissedBlock ValidatorMissedBlock
 			return
 		}
 
-		reportMissedBlockMsgFormat := "MonitorSSV: Validator missed block!\n  Cluster ID: %s\n  Validator Index: %d\n  Epoch: %d\n  Slot: %d\n"
-		msg := fmt.Sprintf(reportMissedBlockMsgFormat, validatorMissedBlock.ClusterId, validatorMissedBlock.Index, validatorMissedBlock.Epoch, validatorMissedBlock.Slot)
+		reportMissedBlockMsgFormat := "MonitorSSV: Validator missed block!\n  Cluster ID: %s\n  Validator Index: %d\n  Epoch: %d\n  Slot: %d\n  Status: %s\n  Reason: %s\n"
+		msg := fmt.Sprintf(reportMissedBlockMsgFormat, validatorMissedBlock.ClusterId, validatorMissedBlock.Index, validatorMissedBlock.Epoch, validatorMissedBlock.Slot, blockStatus(validatorMissedBlock.Provisional), missedReason(validatorMissedBlock.Reason))
 		log.Infow("missedBlockAlarm", "msg", msg)
 		err = alarm.Send(msg)
 		if err != nil {
@@ -629,6 +820,203 @@ func (d *AlarmDaemon) validatorSlashAlarm(validatorSlashNotify ValidatorSlashNot
 	}
 }
 
+func (d *AlarmDaemon) validatorSlashingEvidenceAlarm(validatorSlashingEvidenceNotify ValidatorSlashingEvidenceNotify) {
+	ac, err := d.getClusterAlarmInfo(validatorSlashingEvidenceNotify.ClusterId)
+	if err != nil {
+		log.Errorw("validatorSlashingEvidenceAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("validatorSlashingEvidenceAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportSlashingEvidenceMsgFormat := "MonitorSSV: Slashing included on chain!\n  Cluster ID: %s\n  Epoch: %d\n  Slot: %v\n  Validator Index: %v\n  Operators: %s\n"
+		msg := fmt.Sprintf(reportSlashingEvidenceMsgFormat, validatorSlashingEvidenceNotify.ClusterId, validatorSlashingEvidenceNotify.Epoch,
+			validatorSlashingEvidenceNotify.Slots, validatorSlashingEvidenceNotify.Index, validatorSlashingEvidenceNotify.OperatorIds)
+		if len(validatorSlashingEvidenceNotify.SharedClusters) > 0 {
+			msg += fmt.Sprintf("  Other clusters hit: %s\n  Shared operators: %v, likely a faulty operator\n",
+				strings.Join(validatorSlashingEvidenceNotify.SharedClusters, ", "), validatorSlashingEvidenceNotify.SharedOperators)
+		}
+		log.Infow("validatorSlashingEvidenceAlarm", "msg", msg)
+		err = alarm.Send(msg)
+		if err != nil {
+			log.Warnw("validatorSlashingEvidenceAlarm: Send", "msg", msg, "err", err)
+		}
+	}
+}
+
+func (d *AlarmDaemon) feeRecipientMismatchAlarm(feeRecipientMismatchNotify FeeRecipientMismatchNotify) {
+	ac, err := d.getClusterAlarmInfo(feeRecipientMismatchNotify.ClusterId)
+	if err != nil {
+		log.Errorw("feeRecipientMismatchAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("feeRecipientMismatchAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportFeeRecipientMismatchMsgFormat := "MonitorSSV: Fee recipient mismatch!\n  Cluster ID: %s\n  Epoch: %d\n  Slot: %d\n  Block: %d\n  Validator Index: %d\n  Fee Recipient: %s\n  Expected Recipient: %s\n  Block Value: %s ETH\n  The execution rewards did not go to your fee recipient address, check the operators' configuration.\n"
+		msg := fmt.Sprintf(reportFeeRecipientMismatchMsgFormat, feeRecipientMismatchNotify.ClusterId, feeRecipientMismatchNotify.Epoch, feeRecipientMismatchNotify.Slot,
+			feeRecipientMismatchNotify.BlockNumber, feeRecipientMismatchNotify.Index, feeRecipientMismatchNotify.FeeRecipient, feeRecipientMismatchNotify.ExpectedRecipient,
+			utils.ToETH(feeRecipientMismatchNotify.BlockValue, "%.4f"))
+		log.Infow("feeRecipientMismatchAlarm", "msg", msg)
+		err = alarm.Send(msg)
+		if err != nil {
+			log.Warnw("feeRecipientMismatchAlarm: Send", "msg", msg, "err", err)
+		}
+	}
+}
+
+func (d *AlarmDaemon) validatorActivatedAlarm(validatorActivatedNotify ValidatorActivatedNotify) {
+	ac, err := d.getClusterAlarmInfo(validatorActivatedNotify.ClusterId)
+	if err != nil {
+		log.Errorw("validatorActivatedAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("validatorActivatedAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportValidatorActivatedMsgFormat := "MonitorSSV: Validator activated!\n  Cluster ID: %s\n  Epoch: %d\n  Validator Index: %v\n"
+		for i, batch := range chunkSlice(validatorActivatedNotify.Index, 100) {
+			msg := fmt.Sprintf(reportValidatorActivatedMsgFormat, validatorActivatedNotify.ClusterId, validatorActivatedNotify.Epoch, batch)
+			log.Infow("validatorActivatedAlarm", "batch", i, "msg", msg)
+			err = alarm.Send(msg)
+			if err != nil {
+				log.Warnw("validatorActivatedAlarm: Send", "msg", msg, "err", err)
+			}
+		}
+	}
+}
+
+func (d *AlarmDaemon) validatorWithdrawnAlarm(validatorWithdrawnNotify ValidatorWithdrawnNotify) {
+	ac, err := d.getClusterAlarmInfo(validatorWithdrawnNotify.ClusterId)
+	if err != nil {
+		log.Errorw("validatorWithdrawnAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("validatorWithdrawnAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportValidatorWithdrawnMsgFormat := "MonitorSSV: Validator balance withdrawn!\n  Cluster ID: %s\n  Validator Index: %d\n  Slot: %d\n  Block: %d\n  Amount: %.4f ETH\n  The validator can now be safely removed from SSV.\n"
+		msg := fmt.Sprintf(reportValidatorWithdrawnMsgFormat, validatorWithdrawnNotify.ClusterId, validatorWithdrawnNotify.Index, validatorWithdrawnNotify.Slot,
+			validatorWithdrawnNotify.BlockNumber, float64(validatorWithdrawnNotify.Amount)/1e9)
+		log.Infow("validatorWithdrawnAlarm", "msg", msg)
+		err = alarm.Send(msg)
+		if err != nil {
+			log.Warnw("validatorWithdrawnAlarm: Send", "msg", msg, "err", err)
+		}
+	}
+}
+
+func (d *AlarmDaemon) validatorOfflineAlarm(validatorOfflineNotify ValidatorOfflineNotify) {
+	ac, err := d.getClusterAlarmInfo(validatorOfflineNotify.ClusterId)
+	if err != nil {
+		log.Errorw("validatorOfflineAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		if ac.ReportOfflineEpochs == 0 {
+			log.Infow("validatorOfflineAlarm: ReportOfflineEpochs not set", "eoaOwner", ac.EoaOwner)
+			return
+		}
+
+		offline := reachedOffline(validatorOfflineNotify.Validators, ac.ReportOfflineEpochs)
+		if len(offline) == 0 {
+			return
+		}
+
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("validatorOfflineAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportValidatorOfflineMsgFormat := "MonitorSSV: Validator offline for %d epochs!\n  Cluster ID: %s\n  Epoch: %d\n  Validator Index: %v\n  The validators missed all of their duties, check your operators.\n"
+		for i, batch := range chunkSlice(offline, 100) {
+			msg := fmt.Sprintf(reportValidatorOfflineMsgFormat, ac.ReportOfflineEpochs, validatorOfflineNotify.ClusterId, validatorOfflineNotify.Epoch, batch)
+			log.Infow("validatorOfflineAlarm", "batch", i, "msg", msg)
+			err = alarm.Send(msg)
+			if err != nil {
+				log.Warnw("validatorOfflineAlarm: Send", "msg", msg, "err", err)
+			}
+		}
+	}
+}
+
+// reachedOffline returns the validators whose offline streak reached threshold with this notify, so they are alerted once
+func reachedOffline(validators []ValidatorOffline, threshold uint64) []uint64 {
+	var res []uint64
+	for _, v := range validators {
+		if v.PrevEpochs < threshold && v.Epochs >= threshold {
+			res = append(res, v.Index)
+		}
+	}
+	return res
+}
+
+func (d *AlarmDaemon) validatorRecoveredAlarm(validatorRecoveredNotify ValidatorRecoveredNotify) {
+	ac, err := d.getClusterAlarmInfo(validatorRecoveredNotify.ClusterId)
+	if err != nil {
+		log.Errorw("validatorRecoveredAlarm: getClusterAlarmInfo", "err", err)
+		return
+	}
+
+	if ac != nil {
+		if ac.ReportOfflineEpochs == 0 {
+			log.Infow("validatorRecoveredAlarm: ReportOfflineEpochs not set", "eoaOwner", ac.EoaOwner)
+			return
+		}
+
+		// only the validators that were reported offline are reported recovered
+		var outages []string
+		for _, v := range validatorRecoveredNotify.Validators {
+			if v.Epochs < ac.ReportOfflineEpochs {
+				continue
+			}
+			outages = append(outages, fmt.Sprintf("  Validator %d: offline since epoch %d for %d epochs (%s), %d missed duties\n",
+				v.Index, v.StartEpoch, v.Epochs, v.Duration.String(), v.MissedDuties))
+		}
+		if len(outages) == 0 {
+			return
+		}
+
+		alarm, err := NewAlarm(ac.AlarmType, ac.AlarmChannel)
+		if err != nil {
+			log.Warnw("validatorRecoveredAlarm: NewAlarm", "owner", ac.EoaOwner, "err", err)
+			return
+		}
+
+		reportValidatorRecoveredMsgFormat := "MonitorSSV: Validator back online!\n  Cluster ID: %s\n  Epoch: %d\n%s"
+		for i := 0; i < len(outages); i += 50 {
+			msg := fmt.Sprintf(reportValidatorRecoveredMsgFormat, validatorRecoveredNotify.ClusterId, validatorRecoveredNotify.Epoch, strings.Join(outages[i:min(i+50, len(outages))], ""))
+			log.Infow("validatorRecoveredAlarm", "batch", i/50, "msg", msg)
+			err = alarm.Send(msg)
+			if err != nil {
+				log.Warnw("validatorRecoveredAlarm: Send", "msg", msg, "err", err)
+			}
+		}
+	}
+}
+
 type alarmConfig struct {
 	EoaOwner                   string `json:"eoa_owner"`
 	AlarmType                  int    `json:"alarm_type"`
@@ -639,6 +1027,7 @@ type alarmConfig struct {
 	ReportProposeBlock         bool   `json:"report_propose_block"`
 	ReportMissedBlock          bool   `json:"report_missed_block"`
 	ReportBalanceDecrease      bool   `json:"report_balance_decrease"`
+	ReportOfflineEpochs        uint64 `json:"report_offline_epochs"`
 	ReportExitedButNotRemoved  bool   `json:"report_exited_but_not_removed"`
 	ReportWeekly               bool   `json:"report_weekly"`
 }
@@ -715,12 +1104,30 @@ func decryptAlarmInfo(key []byte, alarmInfo *store.AlarmInfo) (*alarmConfig, err
 	ac.ReportProposeBlock = alarmInfo.ReportProposeBlock
 	ac.ReportMissedBlock = alarmInfo.ReportMissedBlock
 	ac.ReportBalanceDecrease = alarmInfo.ReportBalanceDecrease
+	ac.ReportOfflineEpochs = alarmInfo.ReportOfflineEpochs
 	ac.ReportExitedButNotRemoved = alarmInfo.ReportExitedButNotRemoved
 	ac.ReportWeekly = alarmInfo.ReportWeekly
 
 	return &ac, nil
 }
 
+func blockStatus(provisional bool) string {
+	if provisional {
+		return "provisional, waiting for finalization"
+	}
+	return "finalized"
+}
+
+func missedReason(reason string) string {
+	switch reason {
+	case store.MissedNoBlock:
+		return "no block was produced, check your operators"
+	case store.MissedOrphaned:
+		return "the block was produced but orphaned by the network, usually it was published too late"
+	}
+	return "unknown, no block is canonical for the slot but it may have been produced and orphaned"
+}
+
 func chunkSlice(slice []uint64, chunkSize int) [][]uint64 {
 	var chunks [][]uint64
 	if chunkSize <= 0 {
@@ -765,3 +1172,23 @@ func formatRunaway(liquidationBlock, curBlock uint64) string {
 
 	return fmt.Sprintf("%dd %dh", days, hours)
 }
+
+// formatTopUp is the report line with the deposit restoring the target runway, empty until the upcoming
+// liquidation of the cluster has been calculated
+func formatTopUp(clusterInfo *store.ClusterInfo) string {
+	if clusterInfo.TopUpTargetDays == 0 || !clusterInfo.Active {
+		return ""
+	}
+	amount, ok := big.NewInt(0).SetString(clusterInfo.TopUpAmount, 10)
+	if !ok {
+		return ""
+	}
+	if amount.Sign() == 0 {
+		return fmt.Sprintf("\n  Top Up For %dd Runway: not needed", clusterInfo.TopUpTargetDays)
+	}
+	// round up so the recommendation is never short
+	amount.Add(amount, big.NewInt(1e16-1))
+	amount.Div(amount, big.NewInt(1e16))
+	amount.Mul(amount, big.NewInt(1e16))
+	return fmt.Sprintf("\n  Top Up For %dd Runway: %s ssv", clusterInfo.TopUpTargetDays, utils.ToSSV(amount, "%.2f"))
+}
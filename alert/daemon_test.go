@@ -102,6 +102,35 @@ func TestValidatorSlashAlarm(t *testing.T) {
 	})
 }
 
+func TestReachedOffline(t *testing.T) {
+	validators := []ValidatorOffline{
+		{Index: 1, Epochs: 3, PrevEpochs: 2},
+		{Index: 2, Epochs: 4, PrevEpochs: 3},
+		{Index: 3, Epochs: 2, PrevEpochs: 1},
+		// epochs skipped by the monitor
+		{Index: 4, Epochs: 5, PrevEpochs: 1},
+	}
+	offline := reachedOffline(validators, 3)
+	if len(offline) != 2 || offline[0] != 1 || offline[1] != 4 {
+		t.Fatalf("expected [1 4], got %v", offline)
+	}
+}
+
+func TestFormatTopUp(t *testing.T) {
+	clusterInfo := store.ClusterInfo{Active: true, TopUpAmount: "12341000000000000000", TopUpTargetDays: 180}
+	if topUp := formatTopUp(&clusterInfo); topUp != "\n  Top Up For 180d Runway: 12.35 ssv" {
+		t.Fatalf("unexpected top up %q", topUp)
+	}
+	clusterInfo.TopUpAmount = "0"
+	if topUp := formatTopUp(&clusterInfo); topUp != "\n  Top Up For 180d Runway: not needed" {
+		t.Fatalf("unexpected top up %q", topUp)
+	}
+	clusterInfo.TopUpTargetDays = 0
+	if topUp := formatTopUp(&clusterInfo); topUp != "" {
+		t.Fatalf("unexpected top up %q", topUp)
+	}
+}
+
 func generateIndexs() []uint64 {
 	start := uint64(249366)
 	count := 210
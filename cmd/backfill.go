@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"github.com/monitorssv/monitorssv/config"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth2"
+	client2 "github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+	"github.com/urfave/cli/v2"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var backfillCmd = &cli.Command{
+	Name:  "backfill",
+	Usage: "Backfill the ssv proposals of an epoch range",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "conf-path",
+			Usage: "config.yaml path",
+			Value: "",
+		},
+		&cli.Uint64Flag{
+			Name:     "start-epoch",
+			Usage:    "first epoch to backfill",
+			Required: true,
+		},
+		&cli.Uint64Flag{
+			Name:     "end-epoch",
+			Usage:    "last epoch to backfill, must be finalized",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:  "parallel",
+			Usage: "number of epochs fetched in parallel",
+			Value: 20,
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		cfg, err := config.InitConfig(ctx.String("conf-path"))
+		if err != nil {
+			log.Errorw("InitConfig", "err", err)
+			return err
+		}
+
+		db, err := store.NewStore(cfg)
+		if err != nil {
+			log.Errorw("NewStore", "err", err)
+			return err
+		}
+
+		eth1Client, err := client.NewEth1Client(cfg)
+		if err != nil {
+			log.Errorw("NewEth1Client", "err", err)
+			return err
+		}
+
+		eth2Client := client2.NewClient(cfg.Eth2Endpoints()...)
+		eth2Client.SetQuorum(cfg.Eth2Quorum)
+		beaconMonitor, err := eth2.NewBeaconMonitor(cfg, eth2Client, eth1Client, db, nil)
+		if err != nil {
+			log.Errorw("NewBeaconMonitor", "err", err)
+			return err
+		}
+
+		finalizedEpoch, err := eth2Client.GetFinalizedEpoch()
+		if err != nil {
+			log.Errorw("GetFinalizedEpoch", "err", err)
+			return err
+		}
+		endEpoch := min(ctx.Uint64("end-epoch"), finalizedEpoch)
+
+		backfillCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		report, err := beaconMonitor.Backfill(backfillCtx, ctx.Uint64("start-epoch"), endEpoch, ctx.Int("parallel"))
+		if report != nil {
+			log.Infow("Backfill report", "startEpoch", report.StartEpoch, "endEpoch", report.EndEpoch, "proposals", report.Proposals, "filled", report.Filled, "gaps", len(report.Gaps), "gapSlots", report.Gaps)
+		}
+		if err != nil {
+			log.Errorw("Backfill", "err", err)
+			return err
+		}
+
+		return nil
+	},
+}
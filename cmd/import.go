@@ -81,7 +81,7 @@ var importCmd = &cli.Command{
 			return err
 		}
 
-		chainRoot, err := ssv.GetSSVRewardMerkleRootOnChain(eth1Client.GetClient())
+		chainRoot, err := ssv.GetSSVRewardMerkleRootOnChain(eth1Client.Caller())
 		if err != nil {
 			log.Errorw("GetSSVRewardMerkleRootOnChain", "err", err)
 			sendMsg(err.Error())
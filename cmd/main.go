@@ -33,6 +33,9 @@ func main() {
 		Commands: []*cli.Command{
 			importCmd,
 			runCmd,
+			backfillCmd,
+			reindexCmd,
+			verifyCmd,
 		},
 	}
 
@@ -94,8 +97,9 @@ var runCmd = &cli.Command{
 			return err
 		}
 
-		eth2Client := client2.NewClient(cfg.Eth2Rpc)
-		beaconMonitor, err := eth2.NewBeaconMonitor(cfg, eth2Client, db, alarmDaemon)
+		eth2Client := client2.NewClient(cfg.Eth2Endpoints()...)
+		eth2Client.SetQuorum(cfg.Eth2Quorum)
+		beaconMonitor, err := eth2.NewBeaconMonitor(cfg, eth2Client, eth1Client, db, alarmDaemon)
 		if err != nil {
 			log.Errorw("NewBeaconMonitor", "err", err)
 			return err
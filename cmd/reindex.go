@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/monitorssv/monitorssv/config"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth1/ssv"
+	"github.com/monitorssv/monitorssv/store"
+	"github.com/urfave/cli/v2"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+var reindexCmd = &cli.Command{
+	Name:  "reindex",
+	Usage: "Replay the ssv events from the deploy block into a shadow database, report the differences and optionally swap it in",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "conf-path",
+			Usage: "config.yaml path",
+			Value: "",
+		},
+		&cli.Uint64Flag{
+			Name:  "end-block",
+			Usage: "last block to replay, defaults to the last block processed by the monitor",
+		},
+		&cli.StringFlag{
+			Name:  "report",
+			Usage: "write the json diff report to this file instead of stdout",
+		},
+		&cli.BoolFlag{
+			Name:  "swap",
+			Usage: "replace the live cluster, operator, validator, fee address and event tables, the monitor has to be stopped",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		cfg, err := config.InitConfig(ctx.String("conf-path"))
+		if err != nil {
+			log.Errorw("InitConfig", "err", err)
+			return err
+		}
+
+		db, err := store.NewStore(cfg)
+		if err != nil {
+			log.Errorw("NewStore", "err", err)
+			return err
+		}
+
+		scanBlock, _, err := db.GetScanPoint()
+		if err != nil {
+			log.Errorw("GetScanPoint", "err", err)
+			return err
+		}
+		endBlock := scanBlock
+		if ctx.IsSet("end-block") {
+			endBlock = ctx.Uint64("end-block")
+		}
+		if ctx.Bool("swap") && endBlock != scanBlock {
+			return fmt.Errorf("swap requires replaying up to the scan point %d, not %d", scanBlock, endBlock)
+		}
+
+		shadowCfg := *cfg
+		shadowCfg.Store.DB = cfg.Store.DB + "_reindex"
+		err = db.RecreateDatabase(shadowCfg.Store.DB)
+		if err != nil {
+			log.Errorw("RecreateDatabase", "db", shadowCfg.Store.DB, "err", err)
+			return err
+		}
+		shadow, err := store.NewStore(&shadowCfg)
+		if err != nil {
+			log.Errorw("NewStore", "db", shadowCfg.Store.DB, "err", err)
+			return err
+		}
+
+		eth1Client, err := client.NewEth1Client(cfg)
+		if err != nil {
+			log.Errorw("NewEth1Client", "err", err)
+			return err
+		}
+		ssvScanner, err := ssv.NewSSV(&shadowCfg, eth1Client, shadow, nil)
+		if err != nil {
+			log.Errorw("NewSSV", "err", err)
+			return err
+		}
+
+		reindexCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		go func() {
+			<-reindexCtx.Done()
+			ssvScanner.Stop()
+		}()
+
+		lastProcessedBlock, err := ssvScanner.Reindex(endBlock)
+		if err != nil {
+			log.Errorw("Reindex", "lastProcessedBlock", lastProcessedBlock, "err", err)
+			return err
+		}
+
+		diffs, err := db.DiffReindex(shadow)
+		if err != nil {
+			log.Errorw("DiffReindex", "err", err)
+			return err
+		}
+		for _, diff := range diffs {
+			log.Infow("Reindex diff", "table", diff.Table, "added", len(diff.Added), "removed", len(diff.Removed), "changed", len(diff.Changed))
+		}
+
+		report, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return err
+		}
+		if path := ctx.String("report"); path != "" {
+			err = os.WriteFile(path, report, 0644)
+			if err != nil {
+				log.Errorw("WriteFile", "path", path, "err", err)
+				return err
+			}
+		} else {
+			fmt.Println(string(report))
+		}
+
+		if !ctx.Bool("swap") {
+			return nil
+		}
+
+		backupDB := fmt.Sprintf("%s_backup_%s", cfg.Store.DB, time.Now().Format("20060102150405"))
+		err = db.SwapReindex(shadowCfg.Store.DB, backupDB)
+		if err != nil {
+			log.Errorw("SwapReindex", "err", err)
+			return err
+		}
+		log.Infow("Reindex swapped", "endBlock", endBlock, "backup", backupDB)
+		return nil
+	},
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/monitorssv/monitorssv/config"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth1/ssv"
+	"github.com/monitorssv/monitorssv/store"
+	"github.com/urfave/cli/v2"
+	"os"
+)
+
+var verifyCmd = &cli.Command{
+	Name:  "verify",
+	Usage: "Compare the clusters and operators with the ssv contract views at the last processed block and report the discrepancies",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "conf-path",
+			Usage: "config.yaml path",
+			Value: "",
+		},
+		&cli.IntFlag{
+			Name:  "sample",
+			Usage: "only check this many random clusters and operators, 0 checks all of them",
+		},
+		&cli.BoolFlag{
+			Name:  "repair",
+			Usage: "fix the drifted rows that can be recovered from the chain, the monitor has to be stopped",
+		},
+		&cli.StringFlag{
+			Name:  "report",
+			Usage: "write the json report to this file instead of stdout",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		cfg, err := config.InitConfig(ctx.String("conf-path"))
+		if err != nil {
+			log.Errorw("InitConfig", "err", err)
+			return err
+		}
+
+		db, err := store.NewStore(cfg)
+		if err != nil {
+			log.Errorw("NewStore", "err", err)
+			return err
+		}
+
+		eth1Client, err := client.NewEth1Client(cfg)
+		if err != nil {
+			log.Errorw("NewEth1Client", "err", err)
+			return err
+		}
+		ssvScanner, err := ssv.NewSSV(cfg, eth1Client, db, nil)
+		if err != nil {
+			log.Errorw("NewSSV", "err", err)
+			return err
+		}
+
+		report, err := ssvScanner.Audit(ctx.Int("sample"), ctx.Bool("repair"))
+		if err != nil {
+			log.Errorw("Audit", "err", err)
+			return err
+		}
+
+		// a running monitor applies new events while the rows are compared, which shows up as false discrepancies
+		if scanBlock, _, err := db.GetScanPoint(); err == nil && scanBlock != report.Block {
+			log.Warnw("scan point moved during the audit, rerun with the monitor stopped to confirm", "auditBlock", report.Block, "scanBlock", scanBlock)
+		}
+
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if path := ctx.String("report"); path != "" {
+			err = os.WriteFile(path, data, 0644)
+			if err != nil {
+				log.Errorw("WriteFile", "path", path, "err", err)
+				return err
+			}
+		} else {
+			fmt.Println(string(data))
+		}
+
+		unrepaired := 0
+		for _, d := range report.Discrepancies {
+			if !d.Repaired {
+				unrepaired++
+			}
+		}
+		log.Infow("Audit done", "block", report.Block, "clusters", report.Clusters, "operators", report.Operators,
+			"discrepancies", len(report.Discrepancies), "unrepaired", unrepaired)
+		if unrepaired > 0 {
+			return fmt.Errorf("%d unrepaired discrepancies", unrepaired)
+		}
+		return nil
+	},
+}
@@ -4,15 +4,39 @@ import (
 	"fmt"
 	"github.com/spf13/viper"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 type Config struct {
-	Network   string       `json:"network"`
-	Eth1Rpc   string       `json:"eth1rpc"`
-	Eth2Rpc   string       `json:"eth2rpc"`
-	Store     StoreSetting `json:"store"`
-	EtherScan EtherScan    `json:"etherscan"`
-	Dev       bool         `json:"dev"`
+	Network        string       `json:"network"`
+	Eth1Rpc        string       `json:"eth1rpc"`
+	Eth1Rpcs       []string     `json:"eth1rpcs"`      // extra execution endpoints for failover
+	Eth1RateLimit  float64      `json:"eth1ratelimit"` // requests per second on each eth1 endpoint, 0 is unlimited
+	Eth2Rpc        string       `json:"eth2rpc"`
+	Eth2Rpcs       []string     `json:"eth2rpcs"`       // extra beacon endpoints for failover
+	Eth2Quorum     int          `json:"eth2quorum"`     // endpoints that must agree on finality and validator status
+	Eth1UnsafeHead bool         `json:"eth1unsafehead"` // also record the events above the finalized block as provisional
+	Eth1Subscribe  bool         `json:"eth1subscribe"`  // apply the events as they arrive over a websocket eth1rpc or eth1rpcs, rolled back on a reorg
+	Audit          AuditSetting `json:"audit"`
+	Store          StoreSetting `json:"store"`
+	EtherScan      EtherScan    `json:"etherscan"`
+	Dev            bool         `json:"dev"`
+
+	NetworkFeeSchedule []NetworkFeeChange `json:"networkfeeschedule"` // DAO network fee changes announced for a future block
+	TopUpTargetDays    uint64             `json:"topuptargetdays"`    // runway the top-up recommended by the reports restores, 0 is 180 days
+}
+
+type NetworkFeeChange struct {
+	Block uint64 `yaml:"block"` // block the new fee takes effect
+	Fee   uint64 `yaml:"fee"`   // network fee in SSV wei per block, as getNetworkFee returns it
+}
+
+type AuditSetting struct {
+	Interval int    `yaml:"interval"` // hours between two audits of the store against the contract views, 0 disables the audit
+	Sample   int    `yaml:"sample"`   // clusters and operators checked per audit, 0 checks all of them
+	Repair   bool   `yaml:"repair"`   // fix the drifted rows that can be recovered from the chain
+	Report   string `yaml:"report"`   // write the json report of the last audit to this file
 }
 
 type StoreSetting struct {
@@ -53,22 +77,77 @@ func InitConfig(path string) (*Config, error) {
 }
 
 func (cfg *Config) Validate() error {
-	if cfg.Network != "holesky" && cfg.Network != "mainnet" {
+	if cfg.Network != "holesky" && cfg.Network != "hoodi" && cfg.Network != "mainnet" {
 		return fmt.Errorf("invalid network: %v", cfg.Network)
 	}
 	if cfg.Eth1Rpc == "" {
 		return fmt.Errorf("invalid eth1 rpc: %v", cfg.Network)
 	}
-	if cfg.Network == "mainnet" && cfg.Eth2Rpc == "" {
+	if cfg.Eth1Subscribe && !slices.ContainsFunc(cfg.Eth1Endpoints(), IsWebsocket) {
+		return fmt.Errorf("eth1 subscribe requires a websocket eth1 rpc: %v", cfg.Eth1Endpoints())
+	}
+	if cfg.Network == "mainnet" && len(cfg.Eth2Endpoints()) == 0 {
 		return fmt.Errorf("invalid eth2 rpc: %v", cfg.Network)
 	}
+	if cfg.Audit.Interval < 0 || cfg.Audit.Sample < 0 {
+		return fmt.Errorf("invalid audit interval or sample: %d, %d", cfg.Audit.Interval, cfg.Audit.Sample)
+	}
+	if cfg.Eth1RateLimit < 0 {
+		return fmt.Errorf("invalid eth1 rate limit: %v", cfg.Eth1RateLimit)
+	}
+	if cfg.Eth2Quorum > len(cfg.Eth2Endpoints()) {
+		return fmt.Errorf("invalid eth2 quorum: %d, only %d endpoints", cfg.Eth2Quorum, len(cfg.Eth2Endpoints()))
+	}
 
-	if cfg.Network == "mainnet" && cfg.EtherScan.ApiKey == "" {
-		return fmt.Errorf("invalid etherscan apiKey")
+	if cfg.TopUpTargetDays > 36500 {
+		return fmt.Errorf("invalid top-up target days: %d", cfg.TopUpTargetDays)
 	}
-	if cfg.Network == "mainnet" && cfg.EtherScan.Endpoint == "" {
+
+	for _, change := range cfg.NetworkFeeSchedule {
+		if change.Block == 0 {
+			return fmt.Errorf("invalid network fee schedule: fee %d has no block", change.Fee)
+		}
+	}
+
+	if cfg.EtherScan.ApiKey != "" && cfg.EtherScan.Endpoint == "" {
 		return fmt.Errorf("invalid etherscan endpoint")
 	}
 
 	return nil
 }
+
+// TopUpTargetRunway is the runway in blocks the recommended top-up of a cluster restores
+func (cfg *Config) TopUpTargetRunway() uint64 {
+	if cfg.TopUpTargetDays == 0 {
+		return 180 * 7200
+	}
+	return cfg.TopUpTargetDays * 7200
+}
+
+// IsWebsocket reports whether the endpoint can serve subscriptions
+func IsWebsocket(url string) bool {
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
+// Eth1Endpoints returns eth1rpc followed by eth1rpcs, without duplicates
+func (cfg *Config) Eth1Endpoints() []string {
+	return uniqueEndpoints(append([]string{cfg.Eth1Rpc}, cfg.Eth1Rpcs...))
+}
+
+// Eth2Endpoints returns eth2rpc followed by eth2rpcs, without duplicates
+func (cfg *Config) Eth2Endpoints() []string {
+	return uniqueEndpoints(append([]string{cfg.Eth2Rpc}, cfg.Eth2Rpcs...))
+}
+
+func uniqueEndpoints(urls []string) []string {
+	var endpoints []string
+	seen := make(map[string]bool)
+	for _, endpoint := range urls {
+		if endpoint == "" || seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
@@ -2,65 +2,182 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	logging "github.com/ipfs/go-log/v2"
 	"github.com/monitorssv/monitorssv/config"
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"math/big"
+	"strings"
 	"time"
 )
 
 var timeout = 1 * time.Minute
 
+var log = logging.Logger("eth1-client")
+
+// filterLogsRetryConfig does not retry a block range the endpoints refuse, the caller has to split it
+var filterLogsRetryConfig = utils.RetryConfig{
+	MaxRetries: utils.DefaultRetryConfig.MaxRetries,
+	RetryDelay: utils.DefaultRetryConfig.RetryDelay,
+	Abort:      IsTooManyResults,
+}
+
+// callContractRetryConfig does not retry calls the contract reverted
+var callContractRetryConfig = utils.RetryConfig{
+	MaxRetries: utils.DefaultRetryConfig.MaxRetries,
+	RetryDelay: utils.DefaultRetryConfig.RetryDelay,
+	Abort:      IsExecutionReverted,
+}
+
+// Eth1Client fails over between the eth1 endpoints, each of them is rate limited on its own
 type Eth1Client struct {
-	client *ethclient.Client
+	endpoints *utils.EndpointPool[*rpcClient]
 }
 
 func NewEth1Client(cfg *config.Config) (*Eth1Client, error) {
-	client, err := ethclient.Dial(cfg.Eth1Rpc)
-	if err != nil {
-		return nil, err
+	c := &Eth1Client{endpoints: utils.NewEndpointPool[*rpcClient]("eth1")}
+	for _, url := range cfg.Eth1Endpoints() {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, err
+		}
+		c.endpoints.Add(url, newRpcClient(client, cfg.Eth1RateLimit))
 	}
-	return &Eth1Client{client: client}, nil
+	if c.endpoints.Len() > 1 {
+		go c.healthCheckLoop()
+	}
+	return c, nil
+}
+
+// Caller returns a bind.ContractCaller for the contract bindings and multicalls, its calls go through the
+// endpoint pool like the other requests. The context of a call is replaced by the request timeout.
+func (c *Eth1Client) Caller() bind.ContractCaller {
+	return &contractCaller{c: c}
+}
+
+type contractCaller struct {
+	c *Eth1Client
 }
 
-func (c *Eth1Client) GetClient() *ethclient.Client {
-	return c.client
+func (cc *contractCaller) CodeAt(_ context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return utils.Retry(func() ([]byte, error) {
+		return failover(cc.c, "eth_getCode", func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+			return client.CodeAt(ctx, contract, blockNumber)
+		})
+	}, utils.DefaultRetryConfig)
+}
+
+func (cc *contractCaller) CallContract(_ context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return utils.Retry(func() ([]byte, error) {
+		return failover(cc.c, "eth_call", func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+			return client.CallContract(ctx, call, blockNumber)
+		})
+	}, callContractRetryConfig)
 }
 
 func (c *Eth1Client) BlockNumber() (uint64, error) {
 	return utils.Retry(func() (uint64, error) {
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
+		return failover(c, "eth_blockNumber", func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+			return client.BlockNumber(ctx)
+		})
+	}, utils.DefaultRetryConfig)
+}
 
-		return c.client.BlockNumber(ctx)
+// FinalizedBlockNumber returns the number of the latest finalized block, it can not be reorged anymore
+func (c *Eth1Client) FinalizedBlockNumber() (uint64, error) {
+	return utils.Retry(func() (uint64, error) {
+		return failover(c, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+			header, err := client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+			if err != nil {
+				return 0, err
+			}
+			return header.Number.Uint64(), nil
+		})
 	}, utils.DefaultRetryConfig)
 }
 
 func (c *Eth1Client) CodeAt(account string) ([]byte, error) {
 	return utils.Retry(func() ([]byte, error) {
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-
-		return c.client.CodeAt(ctx, common.HexToAddress(account), nil)
+		return failover(c, "eth_getCode", func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+			return client.CodeAt(ctx, common.HexToAddress(account), nil)
+		})
 	}, utils.DefaultRetryConfig)
 }
 
+// CallContract calls the contract at the latest block
+func (c *Eth1Client) CallContract(contract common.Address, data []byte) ([]byte, error) {
+	return utils.Retry(func() ([]byte, error) {
+		return failover(c, "eth_call", func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+			return client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+		})
+	}, callContractRetryConfig)
+}
+
+// IsExecutionReverted reports whether a call failed because the contract reverted, not the endpoint
+func IsExecutionReverted(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "execution reverted")
+}
+
 func (c *Eth1Client) ChainId() (*big.Int, error) {
 	return utils.Retry(func() (*big.Int, error) {
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-
-		return c.client.ChainID(ctx)
+		return failover(c, "eth_chainId", func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+			return client.ChainID(ctx)
+		})
 	}, utils.DefaultRetryConfig)
 }
 
+// FilterLogs returns an error matching IsTooManyResults right away when no endpoint serves the block range
 func (c *Eth1Client) FilterLogs(q ethereum.FilterQuery) ([]types.Log, error) {
 	return utils.Retry(func() ([]types.Log, error) {
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-		return c.client.FilterLogs(ctx, q)
+		return failover(c, "eth_getLogs", func(ctx context.Context, client *ethclient.Client) ([]types.Log, error) {
+			return client.FilterLogs(ctx, q)
+		})
+	}, filterLogsRetryConfig)
+}
+
+// SubscribeFilterLogs subscribes on the healthiest websocket endpoint. The subscription is not retried.
+func (c *Eth1Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	for _, ep := range c.endpoints.Sorted() {
+		if !config.IsWebsocket(ep.Url) {
+			continue
+		}
+		sub, err := ep.Client.client.SubscribeFilterLogs(ctx, q, ch)
+		if err != nil {
+			ep.Failure(err)
+			log.Warnw("SubscribeFilterLogs: endpoint failed, try next", "endpoint", ep.Url, "err", err)
+			continue
+		}
+		return sub, nil
+	}
+	return nil, fmt.Errorf("no websocket eth1 endpoint available")
+}
+
+func (c *Eth1Client) BlockByNumber(number uint64) (*types.Block, error) {
+	return utils.Retry(func() (*types.Block, error) {
+		return failover(c, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (*types.Block, error) {
+			return client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		})
+	}, utils.DefaultRetryConfig)
+}
+
+func (c *Eth1Client) HeaderByNumber(number uint64) (*types.Header, error) {
+	return utils.Retry(func() (*types.Header, error) {
+		return failover(c, "eth_getBlockByNumber", func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+			return client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		})
+	}, utils.DefaultRetryConfig)
+}
+
+func (c *Eth1Client) BlockReceipts(number uint64) ([]*types.Receipt, error) {
+	return utils.Retry(func() ([]*types.Receipt, error) {
+		return failover(c, "eth_getBlockReceipts", func(ctx context.Context, client *ethclient.Client) ([]*types.Receipt, error) {
+			return client.BlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number)))
+		})
 	}, utils.DefaultRetryConfig)
 }
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"golang.org/x/time/rate"
+	"strings"
+	"time"
+)
+
+const (
+	// an endpoint more than maxHeadLag blocks behind the best known head is only used when the others fail
+	maxHeadLag          = 10
+	healthCheckInterval = 30 * time.Second
+)
+
+// tooManyResults are the messages of providers that limit the block range or result size of eth_getLogs
+var tooManyResults = []string{
+	"query returned more than",
+	"too many results",
+	"block range",
+	"range is too large",
+	"range too large",
+	"response size exceeded",
+}
+
+type rpcClient struct {
+	client  *ethclient.Client
+	limiter *rate.Limiter
+}
+
+// newRpcClient limits the endpoint to rateLimit requests per second, 0 is unlimited
+func newRpcClient(client *ethclient.Client, rateLimit float64) *rpcClient {
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if rateLimit > 0 {
+		limiter = rate.NewLimiter(rate.Limit(rateLimit), max(1, int(rateLimit)))
+	}
+	return &rpcClient{client: client, limiter: limiter}
+}
+
+type endpoint = utils.Endpoint[*rpcClient]
+
+// IsTooManyResults reports whether eth_getLogs failed because of the queried block range, not the endpoint health
+func IsTooManyResults(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range tooManyResults {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// callEndpoint waits for the rate limit of the endpoint and records the outcome of the request in its health
+func callEndpoint[T any](ep *endpoint, fn func(ctx context.Context, client *ethclient.Client) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var result T
+	if err := ep.Client.limiter.Wait(ctx); err != nil {
+		return result, err
+	}
+
+	start := time.Now()
+	result, err := fn(ctx, ep.Client.client)
+	if err != nil {
+		if !IsTooManyResults(err) && !IsExecutionReverted(err) {
+			ep.Failure(err)
+		}
+		return result, err
+	}
+	ep.Success(time.Since(start))
+	return result, nil
+}
+
+// failover calls the healthiest endpoint and fails over to the next one on error,
+// every endpoint executes a reverted call the same way
+func failover[T any](c *Eth1Client, method string, fn func(ctx context.Context, client *ethclient.Client) (T, error)) (T, error) {
+	return utils.Failover(c.endpoints, method, IsExecutionReverted, func(ep *endpoint) (T, error) {
+		return callEndpoint(ep, fn)
+	})
+}
+
+// Health reports the state of every endpoint, ordered by preference
+func (c *Eth1Client) Health() []utils.EndpointHealth {
+	return c.endpoints.Health()
+}
+
+func (c *Eth1Client) healthCheckLoop() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.checkHeads()
+	}
+}
+
+// checkHeads polls the head of every endpoint, so endpoints that fell behind are not preferred
+func (c *Eth1Client) checkHeads() {
+	var best uint64
+	endpoints := c.endpoints.Endpoints()
+	heads := make([]uint64, len(endpoints))
+	for i, ep := range endpoints {
+		head, err := callEndpoint(ep, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+			return client.BlockNumber(ctx)
+		})
+		if err != nil {
+			log.Warnw("checkHeads: BlockNumber", "endpoint", ep.Url, "err", err)
+			continue
+		}
+		heads[i] = head
+		best = max(best, head)
+	}
+
+	for i, ep := range endpoints {
+		if heads[i] == 0 {
+			continue
+		}
+		lagging := best-heads[i] > maxHeadLag
+		ep.SetHead(heads[i], lagging)
+		if lagging {
+			log.Warnw("eth1 endpoint is lagging", "endpoint", ep.Url, "head", heads[i], "best", best)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBlockNumberStub answers every JSON-RPC request with the block number
+func newBlockNumberStub(blockNumber uint64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Id json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%x"}`, req.Id, blockNumber)
+	}))
+}
+
+func newTestClient(t *testing.T, urls ...string) *Eth1Client {
+	c := &Eth1Client{endpoints: utils.NewEndpointPool[*rpcClient]("eth1")}
+	for _, url := range urls {
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.endpoints.Add(url, newRpcClient(client, 0))
+	}
+	return c
+}
+
+func TestFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+	up := newBlockNumberStub(100)
+	defer up.Close()
+
+	client := newTestClient(t, down.URL, up.URL)
+	blockNumber, err := client.BlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blockNumber != 100 {
+		t.Fatalf("expected 100, got %d", blockNumber)
+	}
+
+	health := client.Health()
+	if health[0].Url != up.URL {
+		t.Fatal("healthy endpoint should be preferred")
+	}
+}
+
+func TestLaggingEndpoint(t *testing.T) {
+	behind := newBlockNumberStub(100)
+	defer behind.Close()
+	head := newBlockNumberStub(100 + maxHeadLag + 1)
+	defer head.Close()
+
+	client := newTestClient(t, behind.URL, head.URL)
+	client.checkHeads()
+
+	health := client.Health()
+	if health[0].Url != head.URL || health[0].Lagging {
+		t.Fatalf("endpoint at the head should be preferred: %+v", health)
+	}
+	if !health[1].Lagging || health[1].Head != 100 {
+		t.Fatalf("expected lagging endpoint at 100: %+v", health[1])
+	}
+}
+
+func TestIsTooManyResults(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: nil, expected: false},
+		{err: errors.New("query returned more than 10000 results"), expected: true},
+		{err: fmt.Errorf("operation failed after 3 attempts: %w", errors.New("Log response size exceeded")), expected: true},
+		{err: errors.New("eth_getLogs is limited to a 10,000 block range"), expected: true},
+		{err: errors.New("connection refused"), expected: false},
+	}
+	for _, test := range tests {
+		if IsTooManyResults(test.err) != test.expected {
+			t.Fatalf("%v: expected %v", test.err, test.expected)
+		}
+	}
+}
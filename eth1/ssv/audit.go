@@ -0,0 +1,501 @@
+package ssv
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+)
+
+const auditBatchSize = 100
+
+// clusterStateEvents carry the whole cluster struct, the last one of a cluster holds its on-chain state
+var clusterStateEvents = []string{ValidatorAdded, ValidatorRemoved, ClusterLiquidated, ClusterReactivated, ClusterWithdrawn, ClusterDeposited}
+
+type AuditDiscrepancy struct {
+	Kind     string `json:"kind"` // cluster or operator
+	Id       string `json:"id"`
+	Field    string `json:"field"`
+	Store    string `json:"store"`
+	Chain    string `json:"chain"`
+	Repaired bool   `json:"repaired"`
+	Error    string `json:"error,omitempty"`
+}
+
+type AuditReport struct {
+	Block         uint64             `json:"block"`
+	Time          int64              `json:"time"`
+	Clusters      int                `json:"clusters"`
+	Operators     int                `json:"operators"`
+	Discrepancies []AuditDiscrepancy `json:"discrepancies"`
+}
+
+type chainOperator struct {
+	Owner          common.Address
+	Fee            *big.Int
+	ValidatorCount uint32
+	Private        bool
+	Active         bool
+}
+
+type aggregateFunc func(calls []utils.Struct0) ([][]byte, error)
+
+// Audit compares the clusters and operators of the store with the contract views at the last applied block.
+// sample only checks that many random clusters and operators, 0 checks all of them.
+// With repair the drifted rows are fixed when the correct value can be read from the chain.
+func (s *SSV) Audit(sample int, repair bool) (*AuditReport, error) {
+	// the rows include the live logs applied above the finalized block
+	block := max(s.lastProcessedBlock, s.liveBlock)
+	report := &AuditReport{Block: block, Time: time.Now().UTC().Unix(), Discrepancies: []AuditDiscrepancy{}}
+
+	clusters, err := s.store.GetAllClusters()
+	if err != nil {
+		return nil, err
+	}
+	operators, err := s.store.GetAllOperators()
+	if err != nil {
+		return nil, err
+	}
+	clusters = sampleRows(clusters, sample)
+	operators = sampleRows(operators, sample)
+
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)}
+	aggregate := func(calls []utils.Struct0) ([][]byte, error) {
+		outs, err := multiCall.MulticallCaller.Aggregate(opts, calls)
+		if err != nil {
+			return nil, err
+		}
+		return outs[1].([][]byte), nil
+	}
+
+	ssvLog.Infow("Audit", "block", block, "clusters", len(clusters), "operators", len(operators), "repair", repair)
+	for i := 0; i < len(clusters); i += auditBatchSize {
+		discrepancies, err := s.auditClusters(clusters[i:min(i+auditBatchSize, len(clusters))], aggregate, repair)
+		if err != nil {
+			return nil, err
+		}
+		report.Discrepancies = append(report.Discrepancies, discrepancies...)
+	}
+	for i := 0; i < len(operators); i += auditBatchSize {
+		discrepancies, err := s.auditOperators(operators[i:min(i+auditBatchSize, len(operators))], aggregate, repair)
+		if err != nil {
+			return nil, err
+		}
+		report.Discrepancies = append(report.Discrepancies, discrepancies...)
+	}
+
+	report.Clusters = len(clusters)
+	report.Operators = len(operators)
+	return report, nil
+}
+
+// auditClusters calls getBalance for the active clusters and isLiquidated for the liquidated ones. Both revert
+// unless the cluster struct hashes to the one stored by the contract, so a revert means the stored state drifted.
+func (s *SSV) auditClusters(infos []store.ClusterInfo, aggregate aggregateFunc, repair bool) ([]AuditDiscrepancy, error) {
+	clusters := make([]Cluster, len(infos))
+	calls := make([]utils.Struct0, len(infos))
+	for i, info := range infos {
+		cluster, err := clusterFromInfo(info)
+		if err != nil {
+			return nil, err
+		}
+		call, err := s.clusterStateCall(cluster)
+		if err != nil {
+			return nil, err
+		}
+		clusters[i] = cluster
+		calls[i] = call
+	}
+
+	_, errs, err := aggregateIsolated(calls, aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []AuditDiscrepancy
+	for i, callErr := range errs {
+		if callErr == nil {
+			continue
+		}
+
+		state, err := s.recoverClusterState(clusters[i], aggregate)
+		if err != nil {
+			res = append(res, AuditDiscrepancy{
+				Kind:  "cluster",
+				Id:    clusters[i].ClusterId,
+				Field: "state",
+				Store: formatClusterState(clusters[i].ClusterInfo),
+				Chain: "reverted",
+				Error: err.Error(),
+			})
+			continue
+		}
+
+		discrepancies := compareClusterState(clusters[i].ClusterId, clusters[i].ClusterInfo, *state)
+		if repair {
+			err = s.repairCluster(infos[i], clusters[i], *state)
+			for j := range discrepancies {
+				discrepancies[j].Repaired = err == nil
+				if err != nil {
+					discrepancies[j].Error = err.Error()
+				}
+			}
+		}
+		res = append(res, discrepancies...)
+	}
+	return res, nil
+}
+
+func (s *SSV) clusterStateCall(cluster Cluster) (utils.Struct0, error) {
+	method := getBalance
+	if !cluster.ClusterInfo.Active {
+		method = isLiquidated
+	}
+	data, err := ssvViewABI.Methods[method].Inputs.Pack(cluster.Owner, cluster.OperatorIds, cluster.ClusterInfo)
+	if err != nil {
+		return utils.Struct0{}, err
+	}
+	return utils.Struct0{
+		Target:   s.ssvNetworkViewAdd,
+		CallData: append(ssvViewABI.Methods[method].ID, data...),
+	}, nil
+}
+
+// recoverClusterState reads the cluster struct of the last applied cluster event from its receipt and checks
+// it against the contract. It fails when the drift comes from a missing event, which needs a reindex.
+func (s *SSV) recoverClusterState(cluster Cluster, aggregate aggregateFunc) (*ISSVNetworkCoreCluster, error) {
+	eventInfo, err := s.store.GetLatestClusterEvent(cluster.ClusterId, clusterStateEvents)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.client.BlockReceipts(eventInfo.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var state *ISSVNetworkCoreCluster
+	for _, receipt := range receipts {
+		if receipt.TxHash.Hex() != eventInfo.TxHash {
+			continue
+		}
+		for _, vLog := range receipt.Logs {
+			if vLog.Index != eventInfo.LogIndex {
+				continue
+			}
+			data := make(map[string]interface{})
+			if err = ssvABI.Events[eventInfo.Action].Inputs.UnpackIntoMap(data, vLog.Data); err != nil {
+				return nil, err
+			}
+			clusterBytes, err := json.Marshal(data["cluster"])
+			if err != nil {
+				return nil, err
+			}
+			state = &ISSVNetworkCoreCluster{}
+			if err = json.Unmarshal(clusterBytes, state); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if state == nil {
+		return nil, fmt.Errorf("log %s/%d of block %d not found", eventInfo.TxHash, eventInfo.LogIndex, eventInfo.BlockNumber)
+	}
+
+	call, err := s.clusterStateCall(Cluster{ClusterId: cluster.ClusterId, Owner: cluster.Owner, OperatorIds: cluster.OperatorIds, ClusterInfo: *state})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = aggregate([]utils.Struct0{call}); err != nil {
+		return nil, fmt.Errorf("state of the last %s event does not match the contract: %w", eventInfo.Action, err)
+	}
+	return state, nil
+}
+
+func (s *SSV) repairCluster(info store.ClusterInfo, cluster Cluster, state ISSVNetworkCoreCluster) error {
+	err := s.store.CreateOrUpdateCluster(&store.ClusterInfo{
+		ClusterID:       info.ClusterID,
+		Owner:           info.Owner,
+		OperatorIds:     info.OperatorIds,
+		ValidatorCount:  state.ValidatorCount,
+		NetworkFeeIndex: state.NetworkFeeIndex,
+		Index:           state.Index,
+		Active:          state.Active,
+		Balance:         state.Balance.String(),
+	})
+	if err != nil {
+		return err
+	}
+	ssvLog.Infow("repairCluster", "clusterId", info.ClusterID, "state", formatClusterState(state))
+
+	if s.isSynced.Load() {
+		s.calcLiquidation(cluster.ClusterId, cluster.Owner, cluster.OperatorIds, state)
+	}
+	return nil
+}
+
+// auditOperators reads getOperatorById for every operator and getOperatorFee for the operators the store has as active
+func (s *SSV) auditOperators(infos []store.OperatorInfo, aggregate aggregateFunc, repair bool) ([]AuditDiscrepancy, error) {
+	var calls []utils.Struct0
+	feeCalls := make(map[int]int)
+	for i, info := range infos {
+		data, err := ssvViewABI.Methods[getOperatorById].Inputs.Pack(info.OperatorId)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, utils.Struct0{
+			Target:   s.ssvNetworkViewAdd,
+			CallData: append(ssvViewABI.Methods[getOperatorById].ID, data...),
+		})
+
+		if info.RemoveBlock != 0 {
+			continue
+		}
+		data, err = ssvViewABI.Methods[getOperatorFee].Inputs.Pack(info.OperatorId)
+		if err != nil {
+			return nil, err
+		}
+		feeCalls[i] = len(calls)
+		calls = append(calls, utils.Struct0{
+			Target:   s.ssvNetworkViewAdd,
+			CallData: append(ssvViewABI.Methods[getOperatorFee].ID, data...),
+		})
+	}
+
+	results, errs, err := aggregateIsolated(calls, aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []AuditDiscrepancy
+	call := 0
+	for i, info := range infos {
+		// a revert only concerns this operator, e.g. one the store has but the contract does not know
+		if errs[call] != nil {
+			res = append(res, AuditDiscrepancy{
+				Kind:  "operator",
+				Id:    strconv.FormatUint(info.OperatorId, 10),
+				Field: "state",
+				Store: strconv.FormatBool(info.RemoveBlock == 0),
+				Chain: "reverted",
+				Error: errs[call].Error(),
+			})
+			call++
+			if _, ok := feeCalls[i]; ok {
+				call++
+			}
+			continue
+		}
+		out, err := ssvViewABI.Methods[getOperatorById].Outputs.Unpack(results[call])
+		if err != nil {
+			return nil, err
+		}
+		op := chainOperator{
+			Owner:          out[0].(common.Address),
+			Fee:            out[1].(*big.Int),
+			ValidatorCount: out[2].(uint32),
+			Private:        out[4].(bool),
+			Active:         out[5].(bool),
+		}
+		call++
+
+		// getOperatorFee reverts for removed operators, the active field reports them
+		if feeCall, ok := feeCalls[i]; ok {
+			if errs[feeCall] == nil {
+				op.Fee = new(big.Int).SetBytes(results[feeCall])
+			}
+			call++
+		}
+
+		discrepancies := compareOperator(info, op)
+		if repair {
+			for j := range discrepancies {
+				s.repairOperator(info.OperatorId, &discrepancies[j])
+			}
+		}
+		res = append(res, discrepancies...)
+	}
+	return res, nil
+}
+
+// repairOperator fixes the fields the views return as they are, owner and active are left for a reindex
+func (s *SSV) repairOperator(operatorId uint64, d *AuditDiscrepancy) {
+	var err error
+	switch d.Field {
+	case "operator_fee":
+		err = s.store.UpdateOperatorFee(operatorId, d.Chain)
+		if err == nil && s.isSynced.Load() {
+			s.calcAllClusterLiquidationChan <- operatorId
+		}
+	case "validator_count":
+		var count uint64
+		count, err = strconv.ParseUint(d.Chain, 10, 32)
+		if err == nil {
+			err = s.store.UpdateOperatorValidatorCount(operatorId, uint32(count))
+		}
+	case "privacy_status":
+		err = s.store.UpdateOperatorPrivacyStatus(operatorId, d.Chain == "true")
+	default:
+		return
+	}
+	if err != nil {
+		d.Error = err.Error()
+		return
+	}
+	d.Repaired = true
+	ssvLog.Infow("repairOperator", "operatorId", operatorId, "field", d.Field, "value", d.Chain)
+}
+
+func compareOperator(info store.OperatorInfo, op chainOperator) []AuditDiscrepancy {
+	var res []AuditDiscrepancy
+	add := func(field, storeValue, chainValue string) {
+		if storeValue != chainValue {
+			res = append(res, AuditDiscrepancy{
+				Kind:  "operator",
+				Id:    strconv.FormatUint(info.OperatorId, 10),
+				Field: field,
+				Store: storeValue,
+				Chain: chainValue,
+			})
+		}
+	}
+
+	add("owner", common.HexToAddress(info.Owner).String(), op.Owner.String())
+	add("active", strconv.FormatBool(info.RemoveBlock == 0), strconv.FormatBool(op.Active))
+	// the contract zeroes the fee of removed operators, the store keeps the last one
+	if op.Active {
+		add("operator_fee", info.OperatorFee, op.Fee.String())
+	}
+	add("validator_count", strconv.FormatUint(uint64(info.ValidatorCount), 10), strconv.FormatUint(uint64(op.ValidatorCount), 10))
+	add("privacy_status", strconv.FormatBool(info.PrivacyStatus), strconv.FormatBool(op.Private))
+	return res
+}
+
+func compareClusterState(clusterId string, stored, chain ISSVNetworkCoreCluster) []AuditDiscrepancy {
+	var res []AuditDiscrepancy
+	add := func(field, storeValue, chainValue string) {
+		if storeValue != chainValue {
+			res = append(res, AuditDiscrepancy{
+				Kind:  "cluster",
+				Id:    clusterId,
+				Field: field,
+				Store: storeValue,
+				Chain: chainValue,
+			})
+		}
+	}
+
+	add("validator_count", strconv.FormatUint(uint64(stored.ValidatorCount), 10), strconv.FormatUint(uint64(chain.ValidatorCount), 10))
+	add("network_fee_index", strconv.FormatUint(stored.NetworkFeeIndex, 10), strconv.FormatUint(chain.NetworkFeeIndex, 10))
+	add("index", strconv.FormatUint(stored.Index, 10), strconv.FormatUint(chain.Index, 10))
+	add("active", strconv.FormatBool(stored.Active), strconv.FormatBool(chain.Active))
+	add("balance", stored.Balance.String(), chain.Balance.String())
+	return res
+}
+
+func formatClusterState(cluster ISSVNetworkCoreCluster) string {
+	return fmt.Sprintf("validatorCount=%d networkFeeIndex=%d index=%d active=%t balance=%s",
+		cluster.ValidatorCount, cluster.NetworkFeeIndex, cluster.Index, cluster.Active, cluster.Balance)
+}
+
+// aggregateIsolated runs the calls in one multicall. The multicall reverts as a whole when one call reverts,
+// so the batch is split until the reverting calls are found, their errors are returned in errs.
+func aggregateIsolated(calls []utils.Struct0, aggregate aggregateFunc) ([][]byte, []error, error) {
+	results := make([][]byte, len(calls))
+	errs := make([]error, len(calls))
+	if len(calls) == 0 {
+		return results, errs, nil
+	}
+
+	out, err := aggregate(calls)
+	if err == nil {
+		copy(results, out)
+		return results, errs, nil
+	}
+	if !client.IsExecutionReverted(err) {
+		return nil, nil, err
+	}
+	if len(calls) == 1 {
+		errs[0] = err
+		return results, errs, nil
+	}
+
+	mid := len(calls) / 2
+	for _, part := range [][2]int{{0, mid}, {mid, len(calls)}} {
+		partResults, partErrs, err := aggregateIsolated(calls[part[0]:part[1]], aggregate)
+		if err != nil {
+			return nil, nil, err
+		}
+		copy(results[part[0]:], partResults)
+		copy(errs[part[0]:], partErrs)
+	}
+	return results, errs, nil
+}
+
+func clusterFromInfo(info store.ClusterInfo) (Cluster, error) {
+	operatorIds, err := getOperatorIds(info.OperatorIds)
+	if err != nil {
+		return Cluster{}, err
+	}
+	balance, isOk := big.NewInt(0).SetString(info.Balance, 10)
+	if !isOk {
+		return Cluster{}, fmt.Errorf("failed to parse balance of cluster %s", info.ClusterID)
+	}
+	return Cluster{
+		ClusterId:   info.ClusterID,
+		Owner:       common.HexToAddress(info.Owner),
+		OperatorIds: operatorIds,
+		ClusterInfo: ISSVNetworkCoreCluster{
+			ValidatorCount:  info.ValidatorCount,
+			NetworkFeeIndex: info.NetworkFeeIndex,
+			Index:           info.Index,
+			Active:          info.Active,
+			Balance:         balance,
+		},
+	}, nil
+}
+
+func sampleRows[T any](rows []T, sample int) []T {
+	if sample <= 0 || sample >= len(rows) {
+		return rows
+	}
+	rand.Shuffle(len(rows), func(i, j int) {
+		rows[i], rows[j] = rows[j], rows[i]
+	})
+	return rows[:sample]
+}
+
+// runAudit is the periodic audit, it logs the discrepancies and writes the report when audit.report is set
+func (s *SSV) runAudit() {
+	report, err := s.Audit(s.cfg.Audit.Sample, s.cfg.Audit.Repair)
+	if err != nil {
+		ssvLog.Warnw("runAudit: Audit", "err", err)
+		return
+	}
+	for _, d := range report.Discrepancies {
+		ssvLog.Warnw("audit discrepancy", "kind", d.Kind, "id", d.Id, "field", d.Field, "store", d.Store, "chain", d.Chain, "repaired", d.Repaired, "err", d.Error)
+	}
+	ssvLog.Infow("runAudit", "block", report.Block, "clusters", report.Clusters, "operators", report.Operators, "discrepancies", len(report.Discrepancies))
+
+	if s.cfg.Audit.Report == "" {
+		return
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		ssvLog.Warnw("runAudit: MarshalIndent", "err", err)
+		return
+	}
+	if err = os.WriteFile(s.cfg.Audit.Report, data, 0644); err != nil {
+		ssvLog.Warnw("runAudit: WriteFile", "path", s.cfg.Audit.Report, "err", err)
+	}
+}
@@ -0,0 +1,122 @@
+package ssv
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+	"testing"
+)
+
+func TestAggregateIsolated(t *testing.T) {
+	calls := make([]utils.Struct0, 7)
+	for i := range calls {
+		calls[i] = utils.Struct0{CallData: []byte{byte(i)}}
+	}
+	reverting := map[byte]bool{2: true, 5: true}
+
+	rounds := 0
+	aggregate := func(calls []utils.Struct0) ([][]byte, error) {
+		rounds++
+		var out [][]byte
+		for _, call := range calls {
+			if reverting[call.CallData[0]] {
+				return nil, errors.New("execution reverted: Multicall aggregate: call failed")
+			}
+			out = append(out, []byte{call.CallData[0] + 100})
+		}
+		return out, nil
+	}
+
+	results, errs, err := aggregateIsolated(calls, aggregate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range calls {
+		if reverting[byte(i)] {
+			if errs[i] == nil {
+				t.Fatalf("expected call %d to revert", i)
+			}
+			continue
+		}
+		if errs[i] != nil || results[i][0] != byte(i)+100 {
+			t.Fatalf("unexpected result of call %d: %v %v", i, results[i], errs[i])
+		}
+	}
+	t.Log("rounds", rounds)
+
+	_, _, err = aggregateIsolated(calls, func(calls []utils.Struct0) ([][]byte, error) {
+		return nil, errors.New("connection refused")
+	})
+	if err == nil {
+		t.Fatal("expected the connection error")
+	}
+}
+
+func TestCompareOperator(t *testing.T) {
+	owner := common.HexToAddress("0x6A6C79d8dA4d3B1C8963073529CD026b36817eB6")
+	info := store.OperatorInfo{
+		OperatorId:     7,
+		Owner:          owner.String(),
+		OperatorFee:    "382640000000",
+		ValidatorCount: 10,
+	}
+
+	same := chainOperator{Owner: owner, Fee: big.NewInt(382640000000), ValidatorCount: 10, Active: true}
+	if discrepancies := compareOperator(info, same); len(discrepancies) != 0 {
+		t.Fatalf("expected no discrepancies, got %+v", discrepancies)
+	}
+
+	drifted := chainOperator{Owner: owner, Fee: big.NewInt(0), ValidatorCount: 12, Private: true, Active: false}
+	discrepancies := compareOperator(info, drifted)
+	fields := make(map[string]AuditDiscrepancy)
+	for _, d := range discrepancies {
+		fields[d.Field] = d
+	}
+	if len(fields) != 3 || fields["active"].Chain != "false" || fields["validator_count"].Chain != "12" || fields["privacy_status"].Chain != "true" {
+		t.Fatalf("unexpected discrepancies %+v", discrepancies)
+	}
+	if _, ok := fields["operator_fee"]; ok {
+		t.Fatal("the fee of a removed operator should not be compared")
+	}
+}
+
+func TestAuditOperatorsRevert(t *testing.T) {
+	owner := common.HexToAddress("0x6A6C79d8dA4d3B1C8963073529CD026b36817eB6")
+	infos := []store.OperatorInfo{
+		{OperatorId: 1, Owner: owner.String(), OperatorFee: "100", ValidatorCount: 4},
+		{OperatorId: 2, Owner: owner.String(), OperatorFee: "100", ValidatorCount: 4},
+		{OperatorId: 3, Owner: owner.String(), OperatorFee: "100", ValidatorCount: 4},
+	}
+
+	getOperatorByIdID := string(ssvViewABI.Methods[getOperatorById].ID)
+	aggregate := func(calls []utils.Struct0) ([][]byte, error) {
+		var out [][]byte
+		for _, call := range calls {
+			isOperatorCall := string(call.CallData[:4]) == getOperatorByIdID
+			operatorId := new(big.Int).SetBytes(call.CallData[4:]).Uint64()
+			if operatorId == 2 {
+				return nil, errors.New("execution reverted: OperatorDoesNotExist")
+			}
+			if !isOperatorCall {
+				out = append(out, common.LeftPadBytes(big.NewInt(100).Bytes(), 32))
+				continue
+			}
+			data, err := ssvViewABI.Methods[getOperatorById].Outputs.Pack(owner, big.NewInt(100), uint32(4), common.Address{}, false, true)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data)
+		}
+		return out, nil
+	}
+
+	discrepancies, err := (&SSV{}).auditOperators(infos, aggregate, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(discrepancies) != 1 || discrepancies[0].Id != "2" || discrepancies[0].Chain != "reverted" || discrepancies[0].Error == "" {
+		t.Fatalf("expected only the reverting operator to be reported, got %+v", discrepancies)
+	}
+}
@@ -0,0 +1,51 @@
+package ssv
+
+import "sync"
+
+const (
+	minLogsBatchSize = 10
+	// the batch size doubles again after logsBatchGrowAfter successful requests
+	logsBatchGrowAfter = 20
+)
+
+// logsBatch adapts the block range of eth_getLogs to the limits of the endpoints, up to logsBatchSize blocks
+type logsBatch struct {
+	mu        sync.Mutex
+	size      uint64
+	successes int
+}
+
+func newLogsBatch() *logsBatch {
+	return &logsBatch{size: logsBatchSize}
+}
+
+func (b *logsBatch) Size() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+// shrink halves the batch size, it returns false if the size is already minimal
+func (b *logsBatch) shrink() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.successes = 0
+	if b.size <= minLogsBatchSize {
+		return false
+	}
+	b.size = max(b.size/2, minLogsBatchSize)
+	return true
+}
+
+func (b *logsBatch) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size >= logsBatchSize {
+		return
+	}
+	b.successes++
+	if b.successes >= logsBatchGrowAfter {
+		b.successes = 0
+		b.size = min(b.size*2, logsBatchSize)
+	}
+}
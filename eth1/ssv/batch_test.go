@@ -0,0 +1,34 @@
+package ssv
+
+import "testing"
+
+func TestLogsBatch(t *testing.T) {
+	batch := newLogsBatch()
+	if batch.Size() != logsBatchSize {
+		t.Fatalf("expected %d, got %d", logsBatchSize, batch.Size())
+	}
+
+	for batch.shrink() {
+	}
+	if batch.Size() != minLogsBatchSize {
+		t.Fatalf("expected minimal size %d, got %d", minLogsBatchSize, batch.Size())
+	}
+
+	for i := 0; i < logsBatchGrowAfter-1; i++ {
+		batch.success()
+	}
+	if batch.Size() != minLogsBatchSize {
+		t.Fatalf("grew too early: %d", batch.Size())
+	}
+	batch.success()
+	if batch.Size() != 2*minLogsBatchSize {
+		t.Fatalf("expected %d, got %d", 2*minLogsBatchSize, batch.Size())
+	}
+
+	for i := 0; i < 20*logsBatchGrowAfter; i++ {
+		batch.success()
+	}
+	if batch.Size() != logsBatchSize {
+		t.Fatalf("expected size capped at %d, got %d", logsBatchSize, batch.Size())
+	}
+}
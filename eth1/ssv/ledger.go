@@ -0,0 +1,132 @@
+package ssv
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+)
+
+// deductedDigits is the precision the contract drops from fees and indexes, see DEDUCTED_DIGITS in SSV
+var deductedDigits = big.NewInt(10_000_000)
+
+var transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// recordClusterLedger stores the balance change of a cluster event. prev is the cluster before the event,
+// value the amount of a deposit or withdrawal.
+func (s *SSV) recordClusterLedger(vLog ethtypes.Log, name string, clusterId string, prev *store.ClusterInfo, cluster ISSVNetworkCoreCluster, value *big.Int) error {
+	if name == ClusterLiquidated {
+		liquidated, err := s.liquidatedAmount(vLog)
+		if err != nil {
+			return err
+		}
+		value = liquidated
+	}
+
+	amount, burn := calcLedgerEntry(name, prev, cluster, value)
+
+	blockTime, err := s.blockTime(vLog.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	return s.store.CreateClusterLedger(&store.ClusterLedgerInfo{
+		ClusterID:      clusterId,
+		Action:         name,
+		BlockNumber:    vLog.BlockNumber,
+		BlockTime:      blockTime,
+		Amount:         amount.String(),
+		Burn:           burn.String(),
+		Balance:        cluster.Balance.String(),
+		ValidatorCount: cluster.ValidatorCount,
+		TxHash:         vLog.TxHash.Hex(),
+		LogIndex:       vLog.Index,
+	})
+}
+
+// liquidatedAmount is the balance paid to the liquidator, the transfer directly follows ClusterLiquidated
+// and is missing when nothing was left
+func (s *SSV) liquidatedAmount(vLog ethtypes.Log) (*big.Int, error) {
+	receipts, err := s.client.BlockReceipts(vLog.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		if receipt.TxHash != vLog.TxHash {
+			continue
+		}
+		for _, l := range receipt.Logs {
+			if l.Index != vLog.Index+1 || len(l.Topics) != 3 || l.Topics[0] != transferTopic {
+				continue
+			}
+			if common.BytesToAddress(l.Topics[1].Bytes()) != s.ssvNetworkAddr {
+				continue
+			}
+			return new(big.Int).SetBytes(l.Data), nil
+		}
+	}
+	return big.NewInt(0), nil
+}
+
+// calcLedgerEntry returns the amount paid in and the fee burnt since prev. The cluster balance is settled
+// on every event, so the burn follows from the index changes times the validators before the event.
+func calcLedgerEntry(name string, prev *store.ClusterInfo, cluster ISSVNetworkCoreCluster, value *big.Int) (*big.Int, *big.Int) {
+	prevBalance := big.NewInt(0)
+	if prev != nil {
+		if balance, ok := new(big.Int).SetString(prev.Balance, 10); ok {
+			prevBalance = balance
+		}
+	}
+
+	if name == ClusterLiquidated {
+		// liquidation zeroes the indexes, what was not paid out has been burnt
+		burn := new(big.Int).Sub(prevBalance, value)
+		if burn.Sign() < 0 {
+			burn = big.NewInt(0)
+		}
+		return new(big.Int).Neg(value), burn
+	}
+
+	burn := big.NewInt(0)
+	if prev != nil && prev.Active {
+		usage := new(big.Int).Sub(new(big.Int).SetUint64(cluster.Index), new(big.Int).SetUint64(prev.Index))
+		usage.Add(usage, new(big.Int).Sub(new(big.Int).SetUint64(cluster.NetworkFeeIndex), new(big.Int).SetUint64(prev.NetworkFeeIndex)))
+		usage.Mul(usage, big.NewInt(int64(prev.ValidatorCount)))
+		usage.Mul(usage, deductedDigits)
+		if usage.Sign() > 0 {
+			burn = usage
+		}
+		if burn.Cmp(prevBalance) > 0 {
+			burn = new(big.Int).Set(prevBalance)
+		}
+	}
+
+	switch name {
+	case ClusterDeposited:
+		return new(big.Int).Set(value), burn
+	case ClusterWithdrawn:
+		return new(big.Int).Neg(value), burn
+	}
+
+	// registration and reactivation can deposit, the amount is not in the event
+	amount := new(big.Int).Sub(cluster.Balance, new(big.Int).Sub(prevBalance, burn))
+	return amount, burn
+}
+
+// blockTime returns the timestamp of the block, the last block is cached as its events are handled together
+func (s *SSV) blockTime(number uint64) (int64, error) {
+	s.blockTimeLock.Lock()
+	defer s.blockTimeLock.Unlock()
+
+	if s.blockTimeNumber == number && s.blockTimeValue != 0 {
+		return s.blockTimeValue, nil
+	}
+	header, err := s.client.HeaderByNumber(number)
+	if err != nil {
+		return 0, err
+	}
+	s.blockTimeNumber = number
+	s.blockTimeValue = int64(header.Time)
+	return s.blockTimeValue, nil
+}
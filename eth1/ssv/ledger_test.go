@@ -0,0 +1,77 @@
+package ssv
+
+import (
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+	"testing"
+)
+
+func TestCalcLedgerEntry(t *testing.T) {
+	ssv := func(v int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(v), big.NewInt(1e18))
+	}
+	prev := &store.ClusterInfo{
+		ValidatorCount:  2,
+		NetworkFeeIndex: 1000,
+		Index:           5000,
+		Active:          true,
+		Balance:         ssv(10).String(),
+	}
+	// 2 validators, index +4000 and network fee index +1000: 2 * 5000 * 1e7 wei
+	burnt := big.NewInt(2 * 5000 * 1e7)
+
+	tests := []struct {
+		name       string
+		prev       *store.ClusterInfo
+		cluster    ISSVNetworkCoreCluster
+		value      *big.Int
+		wantAmount *big.Int
+		wantBurn   *big.Int
+	}{
+		{
+			name:       ClusterDeposited,
+			prev:       prev,
+			cluster:    ISSVNetworkCoreCluster{ValidatorCount: 2, NetworkFeeIndex: 2000, Index: 9000, Active: true, Balance: new(big.Int).Sub(ssv(15), burnt)},
+			value:      ssv(5),
+			wantAmount: ssv(5),
+			wantBurn:   burnt,
+		},
+		{
+			name:       ClusterWithdrawn,
+			prev:       prev,
+			cluster:    ISSVNetworkCoreCluster{ValidatorCount: 2, NetworkFeeIndex: 2000, Index: 9000, Active: true, Balance: new(big.Int).Sub(ssv(7), burnt)},
+			value:      ssv(3),
+			wantAmount: ssv(-3),
+			wantBurn:   burnt,
+		},
+		{
+			// first registration with a deposit
+			name:       ValidatorAdded,
+			prev:       nil,
+			cluster:    ISSVNetworkCoreCluster{ValidatorCount: 1, NetworkFeeIndex: 2000, Index: 9000, Active: true, Balance: ssv(20)},
+			wantAmount: ssv(20),
+			wantBurn:   big.NewInt(0),
+		},
+		{
+			name:       ClusterLiquidated,
+			prev:       prev,
+			cluster:    ISSVNetworkCoreCluster{ValidatorCount: 2, Balance: big.NewInt(0)},
+			value:      ssv(4),
+			wantAmount: ssv(-4),
+			wantBurn:   ssv(6),
+		},
+		{
+			name:       ClusterReactivated,
+			prev:       &store.ClusterInfo{ValidatorCount: 2, Active: false, Balance: "0"},
+			cluster:    ISSVNetworkCoreCluster{ValidatorCount: 2, NetworkFeeIndex: 3000, Index: 12000, Active: true, Balance: ssv(8)},
+			wantAmount: ssv(8),
+			wantBurn:   big.NewInt(0),
+		},
+	}
+	for _, tt := range tests {
+		amount, burn := calcLedgerEntry(tt.name, tt.prev, tt.cluster, tt.value)
+		if amount.Cmp(tt.wantAmount) != 0 || burn.Cmp(tt.wantBurn) != 0 {
+			t.Errorf("%s: amount %s burn %s, want %s %s", tt.name, amount, burn, tt.wantAmount, tt.wantBurn)
+		}
+	}
+}
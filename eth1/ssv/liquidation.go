@@ -115,7 +115,7 @@ func (s *SSV) GetSSVLiquidationInfo(cluster Cluster) (*LiquidationInfo, error) {
 		})
 	}
 
-	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.GetClient())
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
 	if err != nil {
 		return nil, err
 	}
@@ -150,7 +150,7 @@ func (s *SSV) GetNetworkFee() (uint64, uint64, error) {
 		CallData: ssvViewABI.Methods[getNetworkFee].ID,
 	})
 
-	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.GetClient())
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
 	if err != nil {
 		return 0, 0, err
 	}
@@ -166,76 +166,3 @@ func (s *SSV) GetNetworkFee() (uint64, uint64, error) {
 
 	return outs[0].(*big.Int).Uint64(), results[0].Uint64(), nil
 }
-
-func (s *SSV) SimulatedCalcLiquidation(cluster Cluster, networkFee uint64, operatorFees []string) (uint64, uint64, error) {
-	if cluster.ClusterInfo.ValidatorCount == 0 {
-		return 0, 0, noValidatorErr
-	}
-
-	if !cluster.ClusterInfo.Active {
-		return 0, 0, alreadyLiquidatedErr
-	}
-
-	curBlock, err := s.client.BlockNumber()
-	if err != nil {
-		return 0, 0, err
-	}
-
-	liquidationInfo, err := s.GetSSVLiquidationInfo(cluster)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	minimumBlocksBeforeLiquidation := int64(liquidationInfo.LiquidationThresholdPeriod)
-
-	burnRate := uint64(0)
-	for _, opFee := range operatorFees {
-		fee, _ := big.NewInt(0).SetString(opFee, 10)
-		burnRate += fee.Uint64()
-	}
-
-	var nFee uint64
-	if networkFee != 0 {
-		nFee = networkFee
-	} else {
-		nFee = liquidationInfo.NetworkFee
-	}
-	fee := nFee + burnRate
-
-	ssvLog.Infow("SimulatedCalcLiquidation", "clusterId", cluster.ClusterId, "storeNetworkFee", networkFee, "chainNetworkFee", liquidationInfo.NetworkFee, "storeOperatorFees", operatorFees, "chainOperatorFees", liquidationInfo.OperatorsFee)
-
-	if liquidationInfo.ClusterBalance.Cmp(liquidationInfo.MinimumLiquidationCollateral) <= 0 {
-		ssvLog.Infow("CalcSimulatedLiquidation:canLiquidated", "clusterId", cluster.ClusterId)
-		return curBlock - 1, fee, nil
-	}
-
-	perLiquidationThreshold := big.NewInt(0).Mul(big.NewInt(minimumBlocksBeforeLiquidation), big.NewInt(int64(fee)))
-	liquidationThreshold := big.NewInt(0).Mul(perLiquidationThreshold, big.NewInt(int64(cluster.ClusterInfo.ValidatorCount)))
-
-	if liquidationInfo.ClusterBalance.Cmp(liquidationThreshold) > 0 {
-		// The number of validators and minimum collateral will affect the liquidation runway
-		reserve := liquidationInfo.MinimumLiquidationCollateral
-		if liquidationThreshold.Cmp(reserve) > 0 {
-			reserve = liquidationThreshold
-		}
-
-		activeBalance := big.NewInt(0).Sub(liquidationInfo.ClusterBalance, reserve)
-
-		preValidatorBalance := big.NewInt(0).Div(activeBalance, big.NewInt(int64(cluster.ClusterInfo.ValidatorCount)))
-		if preValidatorBalance.Uint64() == 0 {
-			ssvLog.Infow("CalcSimulatedLiquidation:canLiquidated", "clusterId", cluster.ClusterId)
-			return curBlock - 1, fee, nil
-		}
-
-		activeBlock := big.NewInt(0).Div(preValidatorBalance, big.NewInt(0).SetUint64(fee)).Uint64()
-		if activeBlock == 0 {
-			ssvLog.Infow("CalcSimulatedLiquidation:canLiquidated", "clusterId", cluster.ClusterId)
-			return curBlock - 1, fee, nil
-		}
-
-		return curBlock + activeBlock, fee, nil
-	}
-
-	ssvLog.Infow("CalcSimulatedLiquidation:canLiquidated", "clusterId", cluster.ClusterId)
-	return curBlock - 1, fee, nil
-}
@@ -3,8 +3,8 @@ package ssv
 import (
 	"encoding/hex"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"math/big"
 )
@@ -35,7 +35,7 @@ func (s *SSV) GetNetworkInfo() (*NetworkInfo, error) {
 		CallData: ssvViewABI.Methods[getValidatorsPerOperatorLimit].ID,
 	})
 
-	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.GetClient())
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +80,7 @@ func (s *SSV) GetOperatorDeclaredFee(operatorIds []uint64) ([]OperatorDeclaredFe
 		})
 	}
 
-	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.GetClient())
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func (s *SSV) GetOperatorEarnings(operatorIds []uint64) ([]string, error) {
 		})
 	}
 
-	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.GetClient())
+	multiCall, err := utils.NewMulticall(s.cfg.Network, s.client.Caller())
 	if err != nil {
 		return nil, err
 	}
@@ -154,7 +154,7 @@ func (s *SSV) GetOperatorEarnings(operatorIds []uint64) ([]string, error) {
 	return operatorEarnings, nil
 }
 
-func GetSSVRewardCumulativeClaimed(client *ethclient.Client, accounts []common.Address) ([]*big.Int, error) {
+func GetSSVRewardCumulativeClaimed(caller bind.ContractCaller, accounts []common.Address) ([]*big.Int, error) {
 	var callStructs = make([]utils.Struct0, 0)
 	for _, account := range accounts {
 		data, err := ssvRewardABI.Methods[cumulativeClaimedFunc].Inputs.Pack(account)
@@ -167,7 +167,7 @@ func GetSSVRewardCumulativeClaimed(client *ethclient.Client, accounts []common.A
 		})
 	}
 
-	multiCall, err := utils.NewMulticall("mainnet", client)
+	multiCall, err := utils.NewMulticall("mainnet", caller)
 	if err != nil {
 		return nil, err
 	}
@@ -185,14 +185,14 @@ func GetSSVRewardCumulativeClaimed(client *ethclient.Client, accounts []common.A
 	return results, nil
 }
 
-func GetSSVRewardMerkleRootOnChain(client *ethclient.Client) (string, error) {
+func GetSSVRewardMerkleRootOnChain(caller bind.ContractCaller) (string, error) {
 	var callStructs = make([]utils.Struct0, 0)
 	callStructs = append(callStructs, utils.Struct0{
 		Target:   ssvRewardContractAddr,
 		CallData: ssvRewardABI.Methods[getMerkleRootFunc].ID,
 	})
 
-	multiCall, err := utils.NewMulticall("mainnet", client)
+	multiCall, err := utils.NewMulticall("mainnet", caller)
 	if err != nil {
 		return "", err
 	}
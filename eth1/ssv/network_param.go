@@ -0,0 +1,125 @@
+package ssv
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+)
+
+// networkParams maps the DAO parameter events to the param they change
+var networkParams = map[string]string{
+	NetworkFeeUpdated:                   store.NetworkParamNetworkFee,
+	OperatorMaximumFeeUpdated:           store.NetworkParamOperatorMaximumFee,
+	LiquidationThresholdPeriodUpdated:   store.NetworkParamLiquidationThresholdPeriod,
+	MinimumLiquidationCollateralUpdated: store.NetworkParamMinimumLiquidationCollateral,
+	OperatorFeeIncreaseLimitUpdated:     store.NetworkParamOperatorFeeIncreaseLimit,
+	DeclareOperatorFeePeriodUpdated:     store.NetworkParamDeclareOperatorFeePeriod,
+	ExecuteOperatorFeePeriodUpdated:     store.NetworkParamExecuteOperatorFeePeriod,
+}
+
+// recordNetworkParam stores the change of a DAO parameter and returns its old and new value,
+// old is nil when it is unknown
+func (s *SSV) recordNetworkParam(vLog ethtypes.Log, event abi.Event) (*big.Int, *big.Int, error) {
+	param := networkParams[event.Name]
+	data, err := event.Inputs.Unpack(vLog.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var oldValue, newValue *big.Int
+	if event.Name == NetworkFeeUpdated {
+		oldValue = data[0].(*big.Int)
+		newValue = data[1].(*big.Int)
+	} else {
+		newValue = toBigInt(data[0])
+		oldValue, err = s.previousNetworkParam(param, vLog.BlockNumber)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	blockTime, err := s.blockTime(vLog.BlockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &store.NetworkParamInfo{
+		Param:       param,
+		BlockNumber: vLog.BlockNumber,
+		BlockTime:   blockTime,
+		NewValue:    newValue.String(),
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+	}
+	if oldValue != nil {
+		info.OldValue = oldValue.String()
+	}
+	if err = s.store.CreateNetworkParam(info); err != nil {
+		return nil, nil, err
+	}
+	return oldValue, newValue, nil
+}
+
+// previousNetworkParam is the value of the last recorded change. The contract is initialized without events,
+// so before the first change the value is read one block earlier, which needs an archive node.
+func (s *SSV) previousNetworkParam(param string, block uint64) (*big.Int, error) {
+	latest, err := s.store.GetLatestNetworkParam(param)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		value, ok := big.NewInt(0).SetString(latest.NewValue, 10)
+		if ok {
+			return value, nil
+		}
+	}
+
+	value, err := s.networkParamAt(param, block-1)
+	if err != nil {
+		ssvLog.Warnw("previousNetworkParam: value before the first change is unknown", "param", param, "block", block, "err", err)
+		return nil, nil
+	}
+	return value, nil
+}
+
+func (s *SSV) networkParamAt(param string, block uint64) (*big.Int, error) {
+	caller, err := NewSsvCaller(s.ssvNetworkViewAdd, s.client.Caller())
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(block)}
+
+	var value uint64
+	switch param {
+	case store.NetworkParamNetworkFee:
+		return caller.GetNetworkFee(opts)
+	case store.NetworkParamMinimumLiquidationCollateral:
+		return caller.GetMinimumLiquidationCollateral(opts)
+	case store.NetworkParamOperatorMaximumFee:
+		value, err = caller.GetMaximumOperatorFee(opts)
+	case store.NetworkParamLiquidationThresholdPeriod:
+		value, err = caller.GetLiquidationThresholdPeriod(opts)
+	case store.NetworkParamOperatorFeeIncreaseLimit:
+		value, err = caller.GetOperatorFeeIncreaseLimit(opts)
+	case store.NetworkParamDeclareOperatorFeePeriod:
+		value, _, err = caller.GetOperatorFeePeriods(opts)
+	case store.NetworkParamExecuteOperatorFeePeriod:
+		_, value, err = caller.GetOperatorFeePeriods(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(value), nil
+}
+
+func toBigInt(v interface{}) *big.Int {
+	switch v := v.(type) {
+	case *big.Int:
+		return v
+	case uint64:
+		return new(big.Int).SetUint64(v)
+	}
+	return big.NewInt(0)
+}
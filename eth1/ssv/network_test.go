@@ -29,7 +29,7 @@ func TestGetOperatorDeclaredFee(t *testing.T) {
 
 func TestGetSSVRewardMerkleRootOnChain(t *testing.T) {
 	ssv := initSSV(t)
-	root, err := GetSSVRewardMerkleRootOnChain(ssv.client.GetClient())
+	root, err := GetSSVRewardMerkleRootOnChain(ssv.client.Caller())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -40,7 +40,7 @@ func TestGetSSVRewardCumulativeClaimed(t *testing.T) {
 	ssv := initSSV(t)
 	addr1 := common.HexToAddress("0x00b09f79228ef82d5925669ab94d6188df24e085")
 	addr2 := common.HexToAddress("0x057f66b1e1308fa4259631e33ff202d244c8ad9c")
-	amounts, err := GetSSVRewardCumulativeClaimed(ssv.client.GetClient(), []common.Address{addr1, addr2})
+	amounts, err := GetSSVRewardCumulativeClaimed(ssv.client.Caller(), []common.Address{addr1, addr2})
 	if err != nil {
 		t.Fatal(err)
 	}
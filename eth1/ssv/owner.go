@@ -0,0 +1,110 @@
+package ssv
+
+import (
+	"bytes"
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/monitorssv/monitorssv/crypto"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"math/big"
+	"slices"
+)
+
+var notSafeErr = errors.New("not a safe")
+
+// GetSafeOwners returns the owners and the threshold of a Safe, notSafeErr when the contract is not a Safe
+func (s *SSV) GetSafeOwners(safe common.Address) ([]common.Address, uint64, error) {
+	out, err := s.client.CallContract(safe, safeABI.Methods[getOwnersFunc].ID)
+	if err != nil {
+		if client.IsExecutionReverted(err) {
+			return nil, 0, notSafeErr
+		}
+		return nil, 0, err
+	}
+	res, err := safeABI.Methods[getOwnersFunc].Outputs.Unpack(out)
+	if err != nil || len(res) != 1 {
+		return nil, 0, notSafeErr
+	}
+	owners, ok := res[0].([]common.Address)
+	if !ok || len(owners) == 0 {
+		return nil, 0, notSafeErr
+	}
+
+	out, err = s.client.CallContract(safe, safeABI.Methods[getThresholdFunc].ID)
+	if err != nil {
+		if client.IsExecutionReverted(err) {
+			return nil, 0, notSafeErr
+		}
+		return nil, 0, err
+	}
+	res, err = safeABI.Methods[getThresholdFunc].Outputs.Unpack(out)
+	if err != nil || len(res) != 1 {
+		return nil, 0, notSafeErr
+	}
+	threshold, ok := res[0].(*big.Int)
+	if !ok || threshold.Sign() == 0 || threshold.Cmp(big.NewInt(int64(len(owners)))) > 0 {
+		return nil, 0, notSafeErr
+	}
+
+	return owners, threshold.Uint64(), nil
+}
+
+// VerifyOwnerSignature reports whether sign is a personal_sign signature of msg for the cluster owner.
+// A Safe accepts the signature of any of its owners, other contracts are asked with EIP-1271 isValidSignature.
+func (s *SSV) VerifyOwnerSignature(owner string, msg []byte, sign []byte) (bool, error) {
+	ownerAddr := common.HexToAddress(owner)
+
+	var signer *common.Address
+	if len(sign) == 65 {
+		// Ecrecover normalizes v in place, the contract has to see the signature as it was sent
+		addr, err := crypto.Ecrecover(msg, bytes.Clone(sign))
+		if err == nil {
+			if addr == owner {
+				return true, nil
+			}
+			recovered := common.HexToAddress(addr)
+			signer = &recovered
+		}
+	}
+
+	code, err := s.client.CodeAt(owner)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, nil
+	}
+
+	if signer != nil {
+		safeOwners, _, err := s.GetSafeOwners(ownerAddr)
+		if err == nil && slices.Contains(safeOwners, *signer) {
+			return true, nil
+		}
+		if err != nil && !errors.Is(err, notSafeErr) {
+			return false, err
+		}
+	}
+
+	return s.isValidSignature(ownerAddr, accounts.TextHash(msg), sign)
+}
+
+func (s *SSV) isValidSignature(contract common.Address, hash []byte, sign []byte) (bool, error) {
+	data, err := safeABI.Methods[isValidSignatureFunc].Inputs.Pack(common.BytesToHash(hash), sign)
+	if err != nil {
+		return false, err
+	}
+	out, err := s.client.CallContract(contract, append(safeABI.Methods[isValidSignatureFunc].ID, data...))
+	if err != nil {
+		if client.IsExecutionReverted(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return isEip1271MagicValue(out), nil
+}
+
+// isEip1271MagicValue checks the abi encoded bytes4 returned by isValidSignature
+func isEip1271MagicValue(out []byte) bool {
+	return len(out) >= 4 && bytes.Equal(out[:4], eip1271MagicValue[:])
+}
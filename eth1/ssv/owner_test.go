@@ -0,0 +1,143 @@
+package ssv
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/monitorssv/monitorssv/config"
+	"github.com/monitorssv/monitorssv/eth1/client"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newOwnerStub serves eth_getCode and eth_call for a Safe with safeOwners and a smart account that accepts validSign
+func newOwnerStub(safe, account common.Address, safeOwners []common.Address, validSign []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+
+		result := func(data []byte) {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, req.Id, hexutil.Encode(data))
+		}
+		revert := func() {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":3,"message":"execution reverted"}}`, req.Id)
+		}
+
+		switch req.Method {
+		case "eth_getCode":
+			var addr common.Address
+			_ = json.Unmarshal(req.Params[0], &addr)
+			if addr == safe || addr == account {
+				result([]byte{0x60, 0x80})
+				return
+			}
+			result(nil)
+		case "eth_call":
+			var call struct {
+				To    common.Address `json:"to"`
+				Input hexutil.Bytes  `json:"input"`
+				Data  hexutil.Bytes  `json:"data"`
+			}
+			_ = json.Unmarshal(req.Params[0], &call)
+			input := call.Input
+			if len(input) == 0 {
+				input = call.Data
+			}
+			method, err := safeABI.MethodById(input)
+			if err != nil {
+				revert()
+				return
+			}
+
+			switch {
+			case call.To == safe && method.Name == getOwnersFunc:
+				out, _ := method.Outputs.Pack(safeOwners)
+				result(out)
+			case call.To == safe && method.Name == getThresholdFunc:
+				out, _ := method.Outputs.Pack(big.NewInt(1))
+				result(out)
+			case call.To == account && method.Name == isValidSignatureFunc:
+				args, _ := method.Inputs.Unpack(input[4:])
+				if !bytes.Equal(args[1].([]byte), validSign) {
+					revert()
+					return
+				}
+				out, _ := method.Outputs.Pack(eip1271MagicValue)
+				result(out)
+			default:
+				revert()
+			}
+		}
+	}))
+}
+
+func TestVerifyOwnerSignature(t *testing.T) {
+	signerKey, _ := ethcrypto.GenerateKey()
+	signer := ethcrypto.PubkeyToAddress(signerKey.PublicKey)
+	otherKey, _ := ethcrypto.GenerateKey()
+
+	msg := []byte("Signature required for cluster ownership. Block: 100")
+	sign := func(key *ecdsa.PrivateKey) []byte {
+		sig, err := ethcrypto.Sign(accounts.TextHash(msg), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig[64] += 27
+		return sig
+	}
+
+	safe := common.HexToAddress("0x00000000000000000000000000000000000005af")
+	account := common.HexToAddress("0x0000000000000000000000000000000000001271")
+	accountSign := []byte("smart account signature")
+	stub := newOwnerStub(safe, account, []common.Address{signer}, accountSign)
+	defer stub.Close()
+
+	eth1Client, err := client.NewEth1Client(&config.Config{Eth1Rpc: stub.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &SSV{client: eth1Client}
+
+	cases := []struct {
+		name  string
+		owner common.Address
+		sign  []byte
+		valid bool
+	}{
+		{"eoa owner", signer, sign(signerKey), true},
+		{"other eoa", signer, sign(otherKey), false},
+		{"safe owner", safe, sign(signerKey), true},
+		{"not a safe owner", safe, sign(otherKey), false},
+		{"eip1271", account, accountSign, true},
+		{"eip1271 rejected", account, sign(signerKey), false},
+	}
+	for _, c := range cases {
+		valid, err := s.VerifyOwnerSignature(c.owner.String(), msg, c.sign)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if valid != c.valid {
+			t.Fatalf("%s: expected %t, got %t", c.name, c.valid, valid)
+		}
+	}
+
+	owners, threshold, err := s.GetSafeOwners(safe)
+	if err != nil || len(owners) != 1 || owners[0] != signer || threshold != 1 {
+		t.Fatalf("unexpected safe owners %v %d %v", owners, threshold, err)
+	}
+	if _, _, err = s.GetSafeOwners(account); err != notSafeErr {
+		t.Fatalf("expected notSafeErr, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package ssv
+
+// Reindex replays the events up to endBlock into the store of s, which has to be empty.
+// No alarms are sent and the liquidation queue is discarded, the liquidation loops recalculate after the swap.
+func (s *SSV) Reindex(endBlock uint64) (uint64, error) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-s.calcLiquidationChan:
+			}
+		}
+	}()
+
+	ssvLog.Infow("Reindex", "startBlock", s.lastProcessedBlock+1, "endBlock", endBlock)
+	lastProcessedBlock, err := s.ScanSSVEvent(s.lastProcessedBlock+1, endBlock)
+	if err != nil {
+		return lastProcessedBlock, err
+	}
+	return lastProcessedBlock, s.store.UpdateScanEth1Block(lastProcessedBlock)
+}
@@ -0,0 +1,299 @@
+package ssv
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/monitorssv/monitorssv/store"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// secondsPerBlock converts the operator fee approval times to blocks
+const secondsPerBlock = 12
+
+// FeeChange is a scheduled fee change, OperatorId 0 is the network fee
+type FeeChange struct {
+	Block      uint64
+	OperatorId uint64
+	Fee        uint64
+}
+
+type RunwayInput struct {
+	Block                        uint64
+	Balance                      *big.Int
+	ValidatorCount               uint32
+	NetworkFee                   uint64
+	OperatorFees                 map[uint64]uint64
+	LiquidationThresholdPeriod   uint64
+	MinimumLiquidationCollateral *big.Int
+	Changes                      []FeeChange
+}
+
+// RunwayPoint is the projected balance at Block, Fee is the fee per validator and block from there on
+type RunwayPoint struct {
+	Block   uint64
+	Balance *big.Int
+	Fee     uint64
+}
+
+// RunwayProjection is linear between the points. LiquidationBlock is 0 when the cluster never becomes liquidatable,
+// Block if it already is.
+type RunwayProjection struct {
+	LiquidationBlock uint64
+	Points           []RunwayPoint
+	Changes          []FeeChange
+}
+
+// ProjectRunway applies each fee change at its block and finds when the cluster becomes liquidatable, with
+// the same math as CalcLiquidation for every segment between two changes
+func ProjectRunway(in RunwayInput) *RunwayProjection {
+	changes := make([]FeeChange, 0, len(in.Changes))
+	for _, change := range in.Changes {
+		if change.Block < in.Block {
+			change.Block = in.Block
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Block < changes[j].Block
+	})
+
+	operatorFees := make(map[uint64]uint64, len(in.OperatorFees))
+	for id, fee := range in.OperatorFees {
+		operatorFees[id] = fee
+	}
+	networkFee := in.NetworkFee
+	fee := func() uint64 {
+		total := networkFee
+		for _, opFee := range operatorFees {
+			total += opFee
+		}
+		return total
+	}
+
+	projection := &RunwayProjection{Changes: changes}
+	validatorCount := big.NewInt(int64(in.ValidatorCount))
+	balance := new(big.Int).Set(in.Balance)
+	block := in.Block
+	next := 0
+	for {
+		// the changes at this block take effect before the balance is checked
+		for next < len(changes) && changes[next].Block <= block {
+			if changes[next].OperatorId == 0 {
+				networkFee = changes[next].Fee
+			} else {
+				operatorFees[changes[next].OperatorId] = changes[next].Fee
+			}
+			next++
+		}
+		curFee := fee()
+		projection.Points = append(projection.Points, RunwayPoint{Block: block, Balance: new(big.Int).Set(balance), Fee: curFee})
+
+		activeBlock, ok := activeBlocks(balance, validatorCount, curFee, in.LiquidationThresholdPeriod, in.MinimumLiquidationCollateral)
+		if !ok {
+			projection.LiquidationBlock = block
+			return projection
+		}
+		// saturate so block+activeBlock cannot wrap around
+		activeBlock = min(activeBlock, math.MaxUint64-block)
+
+		if next == len(changes) {
+			if curFee == 0 {
+				return projection
+			}
+			projection.LiquidationBlock = block + activeBlock
+			projection.Points = append(projection.Points, RunwayPoint{Block: projection.LiquidationBlock, Balance: burn(balance, validatorCount, curFee, activeBlock), Fee: curFee})
+			return projection
+		}
+
+		changeBlock := changes[next].Block
+		if curFee != 0 && block+activeBlock < changeBlock {
+			projection.LiquidationBlock = block + activeBlock
+			projection.Points = append(projection.Points, RunwayPoint{Block: projection.LiquidationBlock, Balance: burn(balance, validatorCount, curFee, activeBlock), Fee: curFee})
+			return projection
+		}
+		balance = burn(balance, validatorCount, curFee, changeBlock-block)
+		block = changeBlock
+	}
+}
+
+// MinimumDeposit is the smallest deposit, rounded up to gwei, that keeps the cluster from becoming
+// liquidatable before targetBlock
+func MinimumDeposit(in RunwayInput, targetBlock uint64) *big.Int {
+	reaches := func(deposit *big.Int) bool {
+		sim := in
+		sim.Balance = new(big.Int).Add(in.Balance, deposit)
+		projection := ProjectRunway(sim)
+		return projection.LiquidationBlock == 0 || projection.LiquidationBlock >= targetBlock
+	}
+
+	low := big.NewInt(0)
+	if reaches(low) {
+		return low
+	}
+	high := big.NewInt(params.Ether)
+	for !reaches(high) {
+		low.Set(high)
+		high.Lsh(high, 1)
+	}
+	// low never reaches the target and high does
+	one := big.NewInt(1)
+	for new(big.Int).Sub(high, low).Cmp(one) > 0 {
+		mid := new(big.Int).Add(low, high)
+		mid.Rsh(mid, 1)
+		if reaches(mid) {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+
+	gwei := big.NewInt(params.GWei)
+	rounded := new(big.Int).Add(high, new(big.Int).Sub(gwei, one))
+	return rounded.Mul(rounded.Div(rounded, gwei), gwei)
+}
+
+// activeBlocks is how long the balance lasts before the cluster is liquidatable at the fee, false if it already is
+func activeBlocks(balance, validatorCount *big.Int, fee uint64, liquidationThresholdPeriod uint64, minimumLiquidationCollateral *big.Int) (uint64, bool) {
+	if balance.Cmp(minimumLiquidationCollateral) <= 0 {
+		return 0, false
+	}
+
+	perLiquidationThreshold := big.NewInt(0).Mul(new(big.Int).SetUint64(liquidationThresholdPeriod), new(big.Int).SetUint64(fee))
+	liquidationThreshold := big.NewInt(0).Mul(perLiquidationThreshold, validatorCount)
+	if balance.Cmp(liquidationThreshold) <= 0 {
+		return 0, false
+	}
+	if fee == 0 {
+		return 0, true
+	}
+
+	reserve := minimumLiquidationCollateral
+	if liquidationThreshold.Cmp(reserve) > 0 {
+		reserve = liquidationThreshold
+	}
+	activeBalance := big.NewInt(0).Sub(balance, reserve)
+	preValidatorBalance := big.NewInt(0).Div(activeBalance, validatorCount)
+	activeBlock := big.NewInt(0).Div(preValidatorBalance, new(big.Int).SetUint64(fee))
+	if activeBlock.Sign() == 0 {
+		return 0, false
+	}
+	if !activeBlock.IsUint64() {
+		return math.MaxUint64, true
+	}
+	return activeBlock.Uint64(), true
+}
+
+func burn(balance, validatorCount *big.Int, fee uint64, blocks uint64) *big.Int {
+	burnt := new(big.Int).Mul(new(big.Int).SetUint64(fee), new(big.Int).SetUint64(blocks))
+	burnt.Mul(burnt, validatorCount)
+	res := new(big.Int).Sub(balance, burnt)
+	if res.Sign() < 0 {
+		return big.NewInt(0)
+	}
+	return res
+}
+
+// ProjectClusterRunway projects the runway of an active cluster from its on chain balance, applying the declared
+// operator fees at the start of their approval window and the configured network fee schedule
+func (s *SSV) ProjectClusterRunway(cluster Cluster) (*RunwayProjection, error) {
+	input, err := s.activeRunwayInput(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectRunway(*input), nil
+}
+
+// ProjectStoredClusterRunway is ProjectClusterRunway for a cluster of the store
+func (s *SSV) ProjectStoredClusterRunway(info store.ClusterInfo) (*RunwayProjection, error) {
+	cluster, err := clusterFromInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	return s.ProjectClusterRunway(cluster)
+}
+
+func (s *SSV) activeRunwayInput(cluster Cluster) (*RunwayInput, error) {
+	if cluster.ClusterInfo.ValidatorCount == 0 {
+		return nil, noValidatorErr
+	}
+	if !cluster.ClusterInfo.Active {
+		return nil, alreadyLiquidatedErr
+	}
+	return s.runwayInput(cluster)
+}
+
+func (s *SSV) runwayInput(cluster Cluster) (*RunwayInput, error) {
+	curBlock, err := s.client.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	liquidationInfo, err := s.GetSSVLiquidationInfo(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &RunwayInput{
+		Block:                        curBlock,
+		Balance:                      liquidationInfo.ClusterBalance,
+		ValidatorCount:               cluster.ClusterInfo.ValidatorCount,
+		NetworkFee:                   liquidationInfo.NetworkFee,
+		OperatorFees:                 make(map[uint64]uint64, len(cluster.OperatorIds)),
+		LiquidationThresholdPeriod:   liquidationInfo.LiquidationThresholdPeriod,
+		MinimumLiquidationCollateral: liquidationInfo.MinimumLiquidationCollateral,
+	}
+
+	changes, err := s.scheduledOperatorFeeChanges(curBlock, cluster.OperatorIds)
+	if err != nil {
+		return nil, err
+	}
+	input.Changes = append(changes, s.scheduledNetworkFeeChanges(curBlock)...)
+
+	for i, operatorId := range cluster.OperatorIds {
+		if i >= len(liquidationInfo.OperatorsFee) {
+			return nil, errors.New("missing operator fee")
+		}
+		input.OperatorFees[operatorId] = liquidationInfo.OperatorsFee[i]
+	}
+	return input, nil
+}
+
+func (s *SSV) scheduledOperatorFeeChanges(curBlock uint64, operatorIds []uint64) ([]FeeChange, error) {
+	var changes []FeeChange
+	now := time.Now().UTC().Unix()
+	for _, operatorId := range operatorIds {
+		operator, err := s.store.GetOperatorByOperatorId(operatorId)
+		if err != nil {
+			return nil, err
+		}
+		if operator == nil || operator.RemoveBlock != 0 {
+			continue
+		}
+		if operator.PendingOperatorFee == "0" || operator.PendingOperatorFee == "" || now >= operator.ApprovalEndTime {
+			continue
+		}
+		fee, ok := new(big.Int).SetString(operator.PendingOperatorFee, 10)
+		if !ok {
+			continue
+		}
+
+		block := curBlock
+		if operator.ApprovalBeginTime > now {
+			block += uint64(operator.ApprovalBeginTime-now) / secondsPerBlock
+		}
+		changes = append(changes, FeeChange{Block: block, OperatorId: operatorId, Fee: fee.Uint64()})
+	}
+	return changes, nil
+}
+
+func (s *SSV) scheduledNetworkFeeChanges(curBlock uint64) []FeeChange {
+	var changes []FeeChange
+	for _, change := range s.cfg.NetworkFeeSchedule {
+		if change.Block > curBlock {
+			changes = append(changes, FeeChange{Block: change.Block, Fee: change.Fee})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,95 @@
+package ssv
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestProjectRunway(t *testing.T) {
+	ssv := func(v int64) *big.Int {
+		return new(big.Int).Mul(big.NewInt(v), big.NewInt(1e18))
+	}
+	in := RunwayInput{
+		Block:                        1000,
+		Balance:                      ssv(100),
+		ValidatorCount:               2,
+		NetworkFee:                   1e9,
+		OperatorFees:                 map[uint64]uint64{1: 1e9, 2: 1e9},
+		LiquidationThresholdPeriod:   100,
+		MinimumLiquidationCollateral: ssv(1),
+	}
+
+	// constant fee: (100 - 1) ssv / 2 validators / 3e9 per block
+	constant := ProjectRunway(in)
+	blocks := func(balance *big.Int, fee int64) uint64 {
+		return new(big.Int).Div(new(big.Int).Div(balance, big.NewInt(2)), big.NewInt(fee)).Uint64()
+	}
+	want := 1000 + blocks(ssv(99), 3e9)
+	if constant.LiquidationBlock != want {
+		t.Fatalf("liquidation block %d, want %d", constant.LiquidationBlock, want)
+	}
+	if len(constant.Points) != 2 || constant.Points[1].Block != want {
+		t.Fatalf("points %v", constant.Points)
+	}
+
+	// operator 1 doubles its fee after 1000 blocks
+	in.Changes = []FeeChange{{Block: 2000, OperatorId: 1, Fee: 2e9}}
+	raised := ProjectRunway(in)
+	balance := new(big.Int).Sub(ssv(100), big.NewInt(1000*2*3e9))
+	want = 2000 + blocks(new(big.Int).Sub(balance, ssv(1)), 4e9)
+	if raised.LiquidationBlock != want {
+		t.Fatalf("liquidation block %d, want %d", raised.LiquidationBlock, want)
+	}
+	if len(raised.Points) != 3 || raised.Points[1].Balance.Cmp(balance) != 0 || raised.Points[1].Fee != 4e9 {
+		t.Fatalf("points %v", raised.Points)
+	}
+
+	// the network fee change lands after the cluster is liquidatable
+	in.Changes = []FeeChange{{Block: constant.LiquidationBlock + 10, Fee: 5e9}}
+	if late := ProjectRunway(in); late.LiquidationBlock != constant.LiquidationBlock {
+		t.Fatalf("liquidation block %d, want %d", late.LiquidationBlock, constant.LiquidationBlock)
+	}
+
+	// a fee the balance can not cover for the threshold period is liquidatable right away
+	in.Changes = []FeeChange{{Block: 1500, OperatorId: 2, Fee: 1e18}}
+	if immediate := ProjectRunway(in); immediate.LiquidationBlock != 1500 {
+		t.Fatalf("liquidation block %d, want 1500", immediate.LiquidationBlock)
+	}
+
+	// a runway beyond uint64 saturates instead of wrapping around
+	in.Changes = nil
+	in.Balance = new(big.Int).Lsh(ssv(1), 128)
+	in.NetworkFee, in.OperatorFees = 1, map[uint64]uint64{}
+	if huge := ProjectRunway(in); huge.LiquidationBlock != math.MaxUint64 {
+		t.Fatalf("liquidation block %d, want %d", huge.LiquidationBlock, uint64(math.MaxUint64))
+	}
+}
+
+func TestMinimumDeposit(t *testing.T) {
+	in := RunwayInput{
+		Block:                        1000,
+		Balance:                      big.NewInt(1e18),
+		ValidatorCount:               1,
+		NetworkFee:                   1e9,
+		OperatorFees:                 map[uint64]uint64{1: 1e9},
+		LiquidationThresholdPeriod:   100,
+		MinimumLiquidationCollateral: big.NewInt(1e17),
+	}
+
+	target := ProjectRunway(in).LiquidationBlock
+	if deposit := MinimumDeposit(in, target); deposit.Sign() != 0 {
+		t.Fatalf("deposit %s, want 0", deposit)
+	}
+
+	target += 1e6
+	deposit := MinimumDeposit(in, target)
+	// 1e6 more blocks at 2e9 per block
+	if deposit.Cmp(big.NewInt(2e15)) != 0 {
+		t.Fatalf("deposit %s, want 2e15", deposit)
+	}
+	in.Balance = new(big.Int).Add(in.Balance, deposit)
+	if got := ProjectRunway(in).LiquidationBlock; got < target {
+		t.Fatalf("liquidation block %d, want at least %d", got, target)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/monitorssv/monitorssv/alert"
+	"github.com/monitorssv/monitorssv/eth1/client"
 	"github.com/monitorssv/monitorssv/store"
 	"math/big"
 	"sort"
@@ -41,6 +42,7 @@ type BlockLogs struct {
 }
 
 const (
+	// logsBatchSize is the largest block range of eth_getLogs
 	logsBatchSize = 5000
 	defaultLogBuf = 8192
 )
@@ -60,11 +62,8 @@ func (s *SSV) fetchEvents(ctx context.Context, startBlock, endBlock uint64) (<-c
 			return
 		}
 
-		for fromBlock := startBlock; fromBlock <= endBlock; fromBlock += logsBatchSize {
-			toBlock := fromBlock + logsBatchSize - 1
-			if toBlock > endBlock {
-				toBlock = endBlock
-			}
+		for fromBlock := startBlock; fromBlock <= endBlock; {
+			toBlock := min(fromBlock+s.logsBatch.Size()-1, endBlock)
 
 			addresses := []common.Address{s.ssvNetworkAddr}
 
@@ -74,10 +73,15 @@ func (s *SSV) fetchEvents(ctx context.Context, startBlock, endBlock uint64) (<-c
 				FromBlock: new(big.Int).SetUint64(fromBlock),
 				ToBlock:   new(big.Int).SetUint64(toBlock),
 			})
+			if client.IsTooManyResults(err) && s.logsBatch.shrink() {
+				ssvLog.Warnw("fetch events: too many results, shrink batch", "fromBlock", fromBlock, "toBlock", toBlock, "batchSize", s.logsBatch.Size())
+				continue
+			}
 			if err != nil {
 				fetchError <- err
 				return
 			}
+			s.logsBatch.success()
 
 			ssvLog.Infow("fetch events",
 				"fromBlock", fromBlock,
@@ -118,6 +122,7 @@ func (s *SSV) fetchEvents(ctx context.Context, startBlock, endBlock uint64) (<-c
 					}
 				}
 			}
+			fromBlock = toBlock + 1
 		}
 	}()
 
@@ -171,6 +176,12 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			continue
 		}
 
+		// the log may have been applied already by an earlier scan
+		if s.store.TxHashLogIndexIsExist(vLog.TxHash.Hex(), vLog.Index) {
+			ssvLog.Infow("skip processed block event", "event", event.Name, "block", vLog.BlockNumber, "txHash", vLog.TxHash.Hex())
+			continue
+		}
+
 		ssvLog.Infow("processing block event", "event", event.Name, "block", vLog.BlockNumber, "txHash", vLog.TxHash.Hex())
 
 		switch event.Name {
@@ -178,13 +189,40 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			if err := s.recordEvent(vLog, vLog.Address.String(), event.Name, ""); err != nil {
 				return err
 			}
-		case OperatorFeeIncreaseLimitUpdated, DeclareOperatorFeePeriodUpdated, ExecuteOperatorFeePeriodUpdated,
-			LiquidationThresholdPeriodUpdated, MinimumLiquidationCollateralUpdated,
-			NetworkEarningsWithdrawn, OperatorMaximumFeeUpdated:
+		case NetworkEarningsWithdrawn:
+			if err := s.recordEvent(vLog, vLog.Address.String(), event.Name, ""); err != nil {
+				return err
+			}
+		case OperatorFeeIncreaseLimitUpdated, DeclareOperatorFeePeriodUpdated, ExecuteOperatorFeePeriodUpdated, OperatorMaximumFeeUpdated:
+			if _, _, err := s.recordNetworkParam(vLog, event); err != nil {
+				return err
+			}
+			if err := s.recordEvent(vLog, vLog.Address.String(), event.Name, ""); err != nil {
+				return err
+			}
+		case LiquidationThresholdPeriodUpdated, MinimumLiquidationCollateralUpdated:
+			oldValue, newValue, err := s.recordNetworkParam(vLog, event)
+			if err != nil {
+				return err
+			}
+			if s.isSynced.Load() {
+				ssvLog.Infow("calculate the liquidation block: allCluster", "event", event.Name)
+				s.calcAllClusterLiquidationChan <- 0 // calc all cluster liquidation block
+
+				s.networkParamChangeAlarmChan <- alert.NetworkParamChangeNotify{
+					Block:    vLog.BlockNumber,
+					Param:    networkParams[event.Name],
+					OldValue: oldValue,
+					NewValue: newValue,
+				}
+			}
 			if err := s.recordEvent(vLog, vLog.Address.String(), event.Name, ""); err != nil {
 				return err
 			}
 		case NetworkFeeUpdated:
+			if _, _, err := s.recordNetworkParam(vLog, event); err != nil {
+				return err
+			}
 			if s.isSynced.Load() {
 				ssvLog.Info("NetworkFeeUpdated: calculate the liquidation block: allCluster")
 				s.calcAllClusterLiquidationChan <- 0 // calc all cluster liquidation block
@@ -216,6 +254,20 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 		case OperatorFeeDeclared:
 			var owner common.Address
 			copy(owner[:], vLog.Topics[1][12:])
+			var operatorId = big.NewInt(0).SetBytes(vLog.Topics[2][:]).Uint64()
+
+			data, err := event.Inputs.Unpack(vLog.Data)
+			if err != nil {
+				return err
+			}
+			operatorFee := data[1].(*big.Int)
+			oldFee, err := s.currentOperatorFee(operatorId)
+			if err != nil {
+				return err
+			}
+			if err = s.recordOperatorFee(vLog, operatorId, event.Name, oldFee, operatorFee.String()); err != nil {
+				return err
+			}
 
 			if err := s.recordEvent(vLog, owner.String(), event.Name, ""); err != nil {
 				return err
@@ -226,7 +278,24 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 
 			var operatorId = big.NewInt(0).SetBytes(vLog.Topics[2][:]).Uint64()
 
-			err := s.store.CancelUpdateOperatorFee(operatorId)
+			// the event has no fee, the cancelled one is the last declaration
+			oldFee, err := s.currentOperatorFee(operatorId)
+			if err != nil {
+				return err
+			}
+			cancelledFee := oldFee
+			declared, err := s.store.GetLatestOperatorFee(operatorId, OperatorFeeDeclared)
+			if err != nil {
+				return err
+			}
+			if declared != nil {
+				cancelledFee = declared.NewFee
+			}
+			if err = s.recordOperatorFee(vLog, operatorId, event.Name, oldFee, cancelledFee); err != nil {
+				return err
+			}
+
+			err = s.store.CancelUpdateOperatorFee(operatorId)
 			if err != nil {
 				ssvLog.Warnw("failed to cancel update pending operator fee", "block", vLog.BlockNumber, "err", err)
 				return err
@@ -333,6 +402,13 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 				return err
 			}
 			operatorFee := data[1].(*big.Int)
+			oldFee, err := s.currentOperatorFee(operatorId)
+			if err != nil {
+				return err
+			}
+			if err = s.recordOperatorFee(vLog, operatorId, event.Name, oldFee, operatorFee.String()); err != nil {
+				return err
+			}
 			if err = s.store.UpdateOperatorFee(operatorId, operatorFee.String()); err != nil {
 				return err
 			}
@@ -469,6 +545,10 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 				return err
 			}
 			clusterId := CalcClusterId(owner, operatorIds)
+			prevCluster, err := s.store.GetClusterByClusterId(clusterId)
+			if err != nil {
+				return err
+			}
 
 			if err = s.store.CreateOrUpdateCluster(&store.ClusterInfo{
 				ClusterID:        clusterId,
@@ -484,6 +564,9 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			}); err != nil {
 				return err
 			}
+			if err = s.recordClusterLedger(vLog, event.Name, clusterId, prevCluster, cluster, nil); err != nil {
+				return err
+			}
 
 			if err = s.store.CreateValidator(&store.ValidatorInfo{
 				ClusterID:         clusterId,
@@ -534,6 +617,10 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 				return err
 			}
 			clusterId := CalcClusterId(owner, operatorIds)
+			prevCluster, err := s.store.GetClusterByClusterId(clusterId)
+			if err != nil {
+				return err
+			}
 
 			if err = s.store.CreateOrUpdateCluster(&store.ClusterInfo{
 				ClusterID:       clusterId,
@@ -548,6 +635,9 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			}); err != nil {
 				return err
 			}
+			if err = s.recordClusterLedger(vLog, event.Name, clusterId, prevCluster, cluster, nil); err != nil {
+				return err
+			}
 
 			if err = s.store.RemoveValidator(hex.EncodeToString(pubKey), clusterId, int64(vLog.BlockNumber)); err != nil {
 				return err
@@ -590,6 +680,10 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 				return err
 			}
 			clusterId := CalcClusterId(owner, operatorIds)
+			prevCluster, err := s.store.GetClusterByClusterId(clusterId)
+			if err != nil {
+				return err
+			}
 
 			if err = s.store.CreateOrUpdateCluster(&store.ClusterInfo{
 				ClusterID:       clusterId,
@@ -604,6 +698,9 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			}); err != nil {
 				return err
 			}
+			if err = s.recordClusterLedger(vLog, event.Name, clusterId, prevCluster, cluster, nil); err != nil {
+				return err
+			}
 
 			if event.Name == ClusterLiquidated {
 				if err = s.store.ClusterLiquidation(clusterId, vLog.BlockNumber); err != nil {
@@ -656,6 +753,10 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 				return err
 			}
 			clusterId := CalcClusterId(owner, operatorIds)
+			prevCluster, err := s.store.GetClusterByClusterId(clusterId)
+			if err != nil {
+				return err
+			}
 
 			if err = s.store.CreateOrUpdateCluster(&store.ClusterInfo{
 				ClusterID:       clusterId,
@@ -670,6 +771,9 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			}); err != nil {
 				return err
 			}
+			if err = s.recordClusterLedger(vLog, event.Name, clusterId, prevCluster, cluster, data[1].(*big.Int)); err != nil {
+				return err
+			}
 
 			if err = s.recordEvent(vLog, owner.String(), event.Name, clusterId); err != nil {
 				return err
@@ -710,6 +814,15 @@ func (s *SSV) processBlockEvents(logs []ethtypes.Log) error {
 			}); err != nil {
 				return err
 			}
+			if err = s.store.CreateFeeAddressChange(&store.FeeAddressChangeInfo{
+				Owner:       owner.String(),
+				FeeAddress:  recipientAddress.String(),
+				BlockNumber: vLog.BlockNumber,
+				TxHash:      vLog.TxHash.Hex(),
+				LogIndex:    vLog.Index,
+			}); err != nil {
+				return err
+			}
 
 			if err = s.recordEvent(vLog, owner.String(), event.Name, ""); err != nil {
 				return err
@@ -757,11 +870,47 @@ func (s *SSV) recordEvent(vLog ethtypes.Log, owner string, name string, clusterI
 			LogIndex:    vLog.Index,
 			Action:      name,
 			ClusterID:   clusterId,
+			BlockHash:   vLog.BlockHash.Hex(),
 		},
 	}
 	return s.store.CreateEvent(events)
 }
 
+// recordOperatorFee stores a fee event in the operator fee history, fees are per block
+func (s *SSV) recordOperatorFee(vLog ethtypes.Log, operatorId uint64, name string, oldFee, newFee string) error {
+	blockTime, err := s.blockTime(vLog.BlockNumber)
+	if err != nil {
+		return err
+	}
+
+	oldFeeInt, _ := new(big.Int).SetString(oldFee, 10)
+	newFeeInt, _ := new(big.Int).SetString(newFee, 10)
+	increase := oldFeeInt != nil && newFeeInt != nil && newFeeInt.Cmp(oldFeeInt) > 0
+
+	return s.store.CreateOperatorFee(&store.OperatorFeeInfo{
+		OperatorId:  operatorId,
+		Action:      name,
+		BlockNumber: vLog.BlockNumber,
+		BlockTime:   blockTime,
+		OldFee:      oldFee,
+		NewFee:      newFee,
+		Increase:    increase,
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+	})
+}
+
+func (s *SSV) currentOperatorFee(operatorId uint64) (string, error) {
+	operatorInfo, err := s.store.GetOperatorByOperatorId(operatorId)
+	if err != nil {
+		return "", err
+	}
+	if operatorInfo == nil {
+		return "0", nil
+	}
+	return operatorInfo.OperatorFee, nil
+}
+
 func (s *SSV) calcLiquidation(clusterId string, owner common.Address, operatorIds []uint64, cluster ISSVNetworkCoreCluster) {
 	s.calcLiquidationChan <- Cluster{
 		ClusterId:   clusterId,
@@ -0,0 +1,127 @@
+package ssv
+
+import (
+	"errors"
+	"fmt"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+)
+
+var ErrInvalidSimulation = errors.New("invalid simulation")
+
+// ClusterSimulation is a hypothetical change to a cluster. Swaps maps an operator of the cluster to the operator
+// replacing it, the balance is assumed to move along with the validators.
+type ClusterSimulation struct {
+	Deposit        *big.Int
+	ValidatorDelta int64
+	Swaps          map[uint64]uint64
+	// TargetRunway in blocks, 0 skips the minimum deposit
+	TargetRunway uint64
+}
+
+// ClusterSimulationResult holds the projection with the simulation applied. MinimumDeposit is what the cluster
+// with the validator and operator changes, but without the hypothetical deposit, needs to reach TargetRunway.
+type ClusterSimulationResult struct {
+	Projection     *RunwayProjection
+	OperatorIds    []uint64
+	ValidatorCount uint32
+	MinimumDeposit *big.Int
+}
+
+func (s *SSV) SimulateClusterRunway(info store.ClusterInfo, sim ClusterSimulation) (*ClusterSimulationResult, error) {
+	if !info.Active {
+		return nil, alreadyLiquidatedErr
+	}
+	cluster, err := clusterFromInfo(info)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorCount := int64(cluster.ClusterInfo.ValidatorCount) + sim.ValidatorDelta
+	if validatorCount <= 0 || validatorCount > int64(^uint32(0)) {
+		return nil, fmt.Errorf("%w: cluster would have %d validators", ErrInvalidSimulation, validatorCount)
+	}
+	if sim.Deposit != nil && sim.Deposit.Sign() < 0 {
+		return nil, fmt.Errorf("%w: negative deposit", ErrInvalidSimulation)
+	}
+	operatorIds, err := swapOperators(cluster.OperatorIds, sim.Swaps)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := s.runwayInput(cluster)
+	if err != nil {
+		return nil, err
+	}
+	input.ValidatorCount = uint32(validatorCount)
+
+	if len(sim.Swaps) != 0 {
+		changes := make([]FeeChange, 0, len(input.Changes))
+		for _, change := range input.Changes {
+			if _, ok := sim.Swaps[change.OperatorId]; !ok {
+				changes = append(changes, change)
+			}
+		}
+		newIds := make([]uint64, 0, len(sim.Swaps))
+		for oldId, newId := range sim.Swaps {
+			delete(input.OperatorFees, oldId)
+			operator, err := s.store.GetOperatorByOperatorId(newId)
+			if err != nil {
+				return nil, err
+			}
+			if operator == nil || operator.RemoveBlock != 0 {
+				return nil, fmt.Errorf("%w: operator %d does not exist or is removed", ErrInvalidSimulation, newId)
+			}
+			fee, ok := new(big.Int).SetString(operator.OperatorFee, 10)
+			if !ok {
+				return nil, fmt.Errorf("failed to parse fee of operator %d", newId)
+			}
+			input.OperatorFees[newId] = fee.Uint64()
+			newIds = append(newIds, newId)
+		}
+		newChanges, err := s.scheduledOperatorFeeChanges(input.Block, newIds)
+		if err != nil {
+			return nil, err
+		}
+		input.Changes = append(changes, newChanges...)
+	}
+
+	res := &ClusterSimulationResult{
+		OperatorIds:    operatorIds,
+		ValidatorCount: input.ValidatorCount,
+	}
+	if sim.TargetRunway != 0 {
+		res.MinimumDeposit = MinimumDeposit(*input, input.Block+sim.TargetRunway)
+	}
+	if sim.Deposit != nil {
+		input.Balance = new(big.Int).Add(input.Balance, sim.Deposit)
+	}
+	res.Projection = ProjectRunway(*input)
+	return res, nil
+}
+
+func swapOperators(operatorIds []uint64, swaps map[uint64]uint64) ([]uint64, error) {
+	res := make([]uint64, 0, len(operatorIds))
+	inCluster := make(map[uint64]bool, len(operatorIds))
+	for _, operatorId := range operatorIds {
+		inCluster[operatorId] = true
+	}
+	for oldId := range swaps {
+		if !inCluster[oldId] {
+			return nil, fmt.Errorf("%w: operator %d is not in the cluster", ErrInvalidSimulation, oldId)
+		}
+	}
+
+	seen := make(map[uint64]bool, len(operatorIds))
+	for _, operatorId := range operatorIds {
+		if newId, ok := swaps[operatorId]; ok {
+			operatorId = newId
+		}
+		if seen[operatorId] {
+			return nil, fmt.Errorf("%w: operator %d appears twice", ErrInvalidSimulation, operatorId)
+		}
+		seen[operatorId] = true
+		res = append(res, operatorId)
+	}
+	return res, nil
+}
@@ -0,0 +1,16 @@
+package ssv
+
+import "testing"
+
+func TestSwapOperators(t *testing.T) {
+	ids, err := swapOperators([]uint64{1, 2, 3, 4}, map[uint64]uint64{2: 7})
+	if err != nil || len(ids) != 4 || ids[1] != 7 {
+		t.Fatalf("ids %v, err %v", ids, err)
+	}
+	if _, err = swapOperators([]uint64{1, 2, 3, 4}, map[uint64]uint64{5: 7}); err == nil {
+		t.Fatal("swapped an operator outside the cluster")
+	}
+	if _, err = swapOperators([]uint64{1, 2, 3, 4}, map[uint64]uint64{2: 3}); err == nil {
+		t.Fatal("swapped to an operator of the cluster")
+	}
+}
@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/monitorssv/monitorssv/alert"
 	"github.com/monitorssv/monitorssv/config"
 	"github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth1/utils"
 	"github.com/monitorssv/monitorssv/store"
 	"math"
 	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -32,11 +35,23 @@ type SSV struct {
 	lastProcessedBlock uint64
 	isSynced           *atomic.Bool
 
+	// liveLogs is nil unless eth1subscribe is enabled. liveBlock is the latest block applied from them,
+	// the rows they changed above lastProcessedBlock are provisional until the block is finalized.
+	liveLogs  chan ethtypes.Log
+	liveBlock uint64
+
 	calcLiquidationChan           chan Cluster
 	calcAllClusterLiquidationChan chan uint64
 
-	networkFeeChangeAlarmChan  chan<- alert.NetworkFeeChangeNotify
-	operatorFeeChangeAlarmChan chan<- alert.OperatorFeeChangeNotify
+	networkFeeChangeAlarmChan   chan<- alert.NetworkFeeChangeNotify
+	networkParamChangeAlarmChan chan<- alert.NetworkParamChangeNotify
+	operatorFeeChangeAlarmChan  chan<- alert.OperatorFeeChangeNotify
+
+	logsBatch *logsBatch
+
+	blockTimeLock   sync.Mutex
+	blockTimeNumber uint64
+	blockTimeValue  int64
 
 	events map[common.Hash]abi.Event
 	close  chan struct{}
@@ -63,17 +78,33 @@ func NewSSV(cfg *config.Config, client *client.Eth1Client, store *store.Store, a
 		isSynced:                      new(atomic.Bool),
 		calcLiquidationChan:           make(chan Cluster, 100),
 		calcAllClusterLiquidationChan: make(chan uint64, 100),
-		networkFeeChangeAlarmChan:     alarm.NetworkFeeChangeChan(),
-		operatorFeeChangeAlarmChan:    alarm.OperatorFeeChangeChan(),
+		logsBatch:                     newLogsBatch(),
 		events:                        GetAllSSVEvent(),
 		close:                         make(chan struct{}),
 	}
+	// there is no alarm daemon when reindexing, the alarms are only sent once synced
+	if alarm != nil {
+		ssv.networkFeeChangeAlarmChan = alarm.NetworkFeeChangeChan()
+		ssv.networkParamChangeAlarmChan = alarm.NetworkParamChangeChan()
+		ssv.operatorFeeChangeAlarmChan = alarm.OperatorFeeChangeChan()
+	}
 	ssv.isSynced.Store(false)
+	if cfg.Eth1Subscribe {
+		ssv.liveLogs = make(chan ethtypes.Log, defaultLogBuf)
+		// the provisional rows of the last run are verified on the first round
+		ssv.liveBlock, err = store.GetLatestProvisionalBlock()
+		if err != nil {
+			return nil, err
+		}
+	}
 	return ssv, nil
 }
 
 func (s *SSV) Start() {
 	go s.ScanSSVEventLoop()
+	if s.liveLogs != nil {
+		go s.SubscribeSSVEventLoop()
+	}
 	go s.UpdateClusterLiquidationLoop()
 	go s.UpdateClusterUpcomingLiquidationLoop()
 	go s.UpdateOperatorLoop()
@@ -93,50 +124,110 @@ func (s *SSV) GetCfg() *config.Config {
 	return s.cfg
 }
 
-const safeBlockRange uint64 = 5
+// GetEndpointHealth reports the state of the eth1 endpoints
+func (s *SSV) GetEndpointHealth() []utils.EndpointHealth {
+	return s.client.Health()
+}
 
+// ScanSSVEventLoop applies the events up to the finalized block, so the cluster, validator and operator rows
+// can not be corrupted by a reorg. With eth1unsafehead the newer events are recorded as provisional events.
+// With eth1subscribe the subscribed logs are applied as soon as they arrive, the rows they change stay provisional
+// until their block is finalized and are rolled back if a reorg drops it. The polling then fills the gaps.
+// The audit also runs here, so no event changes the rows while they are compared with the contract.
 func (s *SSV) ScanSSVEventLoop() {
 	ticker := time.NewTicker(60 * time.Second)
+	var auditTick <-chan time.Time
+	if s.cfg.Audit.Interval > 0 {
+		auditTicker := time.NewTicker(time.Duration(s.cfg.Audit.Interval) * time.Hour)
+		defer auditTicker.Stop()
+		auditTick = auditTicker.C
+	}
 	for {
 		select {
 		case <-s.close:
 			return
+		case <-auditTick:
+			if !s.isSynced.Load() {
+				continue
+			}
+			s.runAudit()
 		case <-ticker.C:
-			curBlock, err := s.client.BlockNumber()
+			toBlock, err := s.client.FinalizedBlockNumber()
 			if err != nil {
-				ssvLog.Errorf("error getting block number: %s", err)
+				ssvLog.Errorf("error getting finalized block number: %s", err)
 				continue
 			}
-			toBlock := curBlock - safeBlockRange
-			if s.lastProcessedBlock+1 > toBlock {
+
+			// provisional events are rebuilt every round, which also drops the reorged ones
+			err = s.store.DeleteProvisionalEvents()
+			if err != nil {
+				ssvLog.Errorf("ScanSSVEventLoop: DeleteProvisionalEvents failed: %s", err)
 				continue
 			}
 
-			ssvLog.Infow("ScanSSVEventLoop", "lastProcessedBlock", s.lastProcessedBlock, "toBlock", toBlock, "curBlock", curBlock)
-
-			lastProcessedBlock, err := s.ScanSSVEvent(s.lastProcessedBlock+1, toBlock)
+			// the applied live logs must still be canonical before the scan skips them as processed
+			err = s.verifyProvisionalRows(toBlock)
 			if err != nil {
-				ssvLog.Errorf("ScanSSVEventLoop: ScanSSVEvent failed: %s", err)
+				ssvLog.Errorf("ScanSSVEventLoop: verifyProvisionalRows failed: %s", err)
+				continue
 			}
 
-			if lastProcessedBlock > s.lastProcessedBlock {
-				s.lastProcessedBlock = lastProcessedBlock
-				err = s.store.UpdateScanEth1Block(lastProcessedBlock)
+			if s.lastProcessedBlock+1 <= toBlock {
+				ssvLog.Infow("ScanSSVEventLoop", "lastProcessedBlock", s.lastProcessedBlock, "toBlock", toBlock)
+
+				lastProcessedBlock, err := s.ScanSSVEvent(s.lastProcessedBlock+1, toBlock)
 				if err != nil {
-					ssvLog.Warnf("ScanSSVEventLoop: UpdateScanEth1Block failed: %s", err)
+					ssvLog.Errorf("ScanSSVEventLoop: ScanSSVEvent failed: %s", err)
+				}
+
+				s.advanceLastProcessedBlock(lastProcessedBlock)
+
+				if !s.isSynced.Load() {
+					if lastProcessedBlock >= toBlock {
+						ssvLog.Infow("ScanSSVEventLoop: Sync completed", "lastProcessedBlock", lastProcessedBlock, "toBlock", toBlock)
+						s.isSynced.Store(true)
+					}
 				}
 			}
 
+			// the subscribed events are applied already, provisional events would mark them as processed
+			if !s.cfg.Eth1UnsafeHead || s.liveLogs != nil || !s.isSynced.Load() {
+				continue
+			}
+
+			curBlock, err := s.client.BlockNumber()
+			if err != nil {
+				ssvLog.Errorf("error getting block number: %s", err)
+				continue
+			}
+			err = s.scanUnsafeHead(s.lastProcessedBlock+1, curBlock)
+			if err != nil {
+				ssvLog.Warnf("ScanSSVEventLoop: scanUnsafeHead failed: %s", err)
+			}
+		case vLog := <-s.liveLogs:
+			// until the first sync completes the polling catches up on its own
 			if !s.isSynced.Load() {
-				if lastProcessedBlock >= toBlock {
-					ssvLog.Infow("ScanSSVEventLoop: Sync completed", "lastProcessedBlock", lastProcessedBlock, "toBlock", toBlock)
-					s.isSynced.Store(true)
-				}
+				continue
+			}
+			err := s.handleLiveLog(vLog)
+			if err != nil {
+				ssvLog.Errorf("ScanSSVEventLoop: handleLiveLog failed: %s", err)
 			}
 		}
 	}
 }
 
+func (s *SSV) advanceLastProcessedBlock(block uint64) {
+	if block <= s.lastProcessedBlock {
+		return
+	}
+	s.lastProcessedBlock = block
+	err := s.store.UpdateScanEth1Block(block)
+	if err != nil {
+		ssvLog.Warnf("UpdateScanEth1Block failed: %s", err)
+	}
+}
+
 func (s *SSV) UpdateClusterUpcomingLiquidationLoop() {
 	now := time.Now().UTC()
 	nextTime := time.Date(now.Year(), now.Month(), now.Day()+1, 23, 0, 0, 0, time.UTC)
@@ -370,36 +461,10 @@ func (s *SSV) calcAndUpdateClusterLiquidation(cluster Cluster) error {
 	return nil
 }
 
+// simulatedCalcAndUpdateClusterLiquidation stores the projected liquidation block and the top-up restoring the
+// target runway, with the declared operator fees and the scheduled network fees applied at their block
 func (s *SSV) simulatedCalcAndUpdateClusterLiquidation(cluster Cluster) error {
-	networkFee := uint64(0)
-	storeNetworkInfo, err := s.store.GetNetworkInfo()
-	if err != nil {
-		return err
-	}
-	if storeNetworkInfo != nil {
-		networkFee = storeNetworkInfo.UpcomingNetworkFee
-	}
-
-	operatorFees := make([]string, 0)
-	for _, operatorId := range cluster.OperatorIds {
-		operator, err := s.store.GetOperatorByOperatorId(operatorId)
-		if err != nil {
-			return err
-		}
-
-		if operator.RemoveBlock != 0 {
-			operatorFees = append(operatorFees, "0")
-			continue
-		}
-
-		if operator.PendingOperatorFee != "0" && time.Now().UTC().Unix() < operator.ApprovalEndTime {
-			operatorFees = append(operatorFees, operator.PendingOperatorFee)
-		} else {
-			operatorFees = append(operatorFees, operator.OperatorFee)
-		}
-	}
-
-	liquidationBlock, burnFee, err := s.SimulatedCalcLiquidation(cluster, networkFee, operatorFees)
+	input, err := s.activeRunwayInput(cluster)
 	if err != nil {
 		if errors.Is(err, noValidatorErr) || errors.Is(err, alreadyLiquidatedErr) {
 			return nil
@@ -408,14 +473,18 @@ func (s *SSV) simulatedCalcAndUpdateClusterLiquidation(cluster Cluster) error {
 		ssvLog.Warnw("failed to calc liquidation block", "clusterId", cluster.ClusterId, "err", err)
 		return err
 	}
+	projection := ProjectRunway(*input)
+	burnFee := projection.Points[len(projection.Points)-1].Fee
+	topUpTarget := s.cfg.TopUpTargetRunway()
+	topUpAmount := MinimumDeposit(*input, input.Block+topUpTarget)
 	upcomingCalcTime := time.Now().UTC().Unix()
-	err = s.store.UpdateUpcomingClusterLiquidationInfo(cluster.ClusterId, liquidationBlock, upcomingCalcTime, burnFee)
+	err = s.store.UpdateUpcomingClusterLiquidationInfo(cluster.ClusterId, projection.LiquidationBlock, upcomingCalcTime, burnFee, topUpAmount.String(), topUpTarget/7200)
 	if err != nil {
 		ssvLog.Warnw("failed to update liquidation block", "clusterId", cluster.ClusterId, "err", err)
 		return err
 	}
 
-	ssvLog.Infow("cluster simulated calc liquidation block", "clusterId", cluster.ClusterId, "liquidationBlock", liquidationBlock, "upcomingCalcTime", upcomingCalcTime, "upcomingBurnFee", burnFee)
+	ssvLog.Infow("cluster simulated calc liquidation block", "clusterId", cluster.ClusterId, "liquidationBlock", projection.LiquidationBlock, "changes", len(projection.Changes), "upcomingCalcTime", upcomingCalcTime, "upcomingBurnFee", burnFee, "topUpAmount", topUpAmount)
 	return nil
 }
 
@@ -569,6 +638,7 @@ func (s *SSV) updateOperatorName() {
 
 func (s *SSV) UpdateClusterEoaOwnerLoop() {
 	ticker := time.NewTicker(60 * time.Second)
+	safeOwnersResolved := false
 	for {
 		select {
 		case <-s.close:
@@ -578,12 +648,51 @@ func (s *SSV) UpdateClusterEoaOwnerLoop() {
 				continue
 			}
 
+			if !safeOwnersResolved {
+				safeOwnersResolved = s.resolveSafeEoaOwners()
+			}
 			s.updateClusterEoaOwner()
 		}
 	}
 
 }
 
+// resolveSafeEoaOwners sets the eoa owner of the Safes which were resolved to their creator before Safes were
+// detected back to the Safe itself, it returns false if some owners have to be checked again
+func (s *SSV) resolveSafeEoaOwners() bool {
+	owners, err := s.store.GetCreatorResolvedClustersOwner()
+	if err != nil {
+		ssvLog.Warnf("failed to GetCreatorResolvedClustersOwner: %v", err)
+		return false
+	}
+
+	ticker := time.NewTicker(250 * time.Millisecond) // limit 5 calls/s
+	defer ticker.Stop()
+
+	done := true
+	for _, owner := range owners {
+		<-ticker.C
+
+		safeOwners, threshold, err := s.GetSafeOwners(common.HexToAddress(owner))
+		if errors.Is(err, notSafeErr) {
+			continue
+		}
+		if err != nil {
+			ssvLog.Warnf("failed to GetSafeOwners: %v", err)
+			done = false
+			continue
+		}
+
+		ssvLog.Infow("cluster owner is a safe", "owner", owner, "safeOwners", safeOwners, "threshold", threshold)
+		err = s.store.UpdateClusterEoaOwner(owner, owner)
+		if err != nil {
+			ssvLog.Warnf("failed to UpdateClusterEoaOwner: %v", err)
+			done = false
+		}
+	}
+	return done
+}
+
 func (s *SSV) updateClusterEoaOwner() {
 	owners, err := s.store.GetNoUpdatedClustersOwner()
 	if err != nil {
@@ -625,6 +734,31 @@ func (s *SSV) updateClusterEoaOwner() {
 	for _, owner := range contractOwners {
 		<-ticker.C
 
+		// any signer of a Safe manages its monitoring, the deployer is often a relayer of the Safe factory
+		safeOwners, threshold, err := s.GetSafeOwners(common.HexToAddress(owner))
+		if err == nil {
+			ssvLog.Infow("cluster owner is a safe", "owner", owner, "safeOwners", safeOwners, "threshold", threshold)
+			err = s.store.UpdateClusterEoaOwner(owner, owner)
+			if err != nil {
+				ssvLog.Warnf("failed to UpdateClusterEoaOwner: %v", err)
+			}
+			continue
+		}
+		if !errors.Is(err, notSafeErr) {
+			ssvLog.Warnf("failed to GetSafeOwners: %v", err)
+			continue
+		}
+
+		// without etherscan other smart accounts manage their monitoring with EIP-1271 signatures
+		if s.cfg.EtherScan.ApiKey == "" {
+			ssvLog.Infow("cluster owner", "owner", owner, "eoaOwner", owner)
+			err = s.store.UpdateClusterEoaOwner(owner, owner)
+			if err != nil {
+				ssvLog.Warnf("failed to UpdateClusterEoaOwner: %v", err)
+			}
+			continue
+		}
+
 		info, err := GetContractCreator(owner, s.cfg.EtherScan.Endpoint, s.cfg.EtherScan.ApiKey)
 		if err != nil {
 			ssvLog.Warnf("failed to GetContractCreator: %v", err)
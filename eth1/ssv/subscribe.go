@@ -0,0 +1,217 @@
+package ssv
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/monitorssv/monitorssv/store"
+	"sort"
+	"time"
+)
+
+const resubscribeDelay = 10 * time.Second
+
+// SubscribeSSVEventLoop forwards the contract logs of new blocks to ScanSSVEventLoop, which applies them in order
+func (s *SSV) SubscribeSSVEventLoop() {
+	for {
+		err := s.subscribeSSVEvents()
+		if err != nil {
+			ssvLog.Warnw("SubscribeSSVEventLoop: subscription failed", "err", err)
+		}
+
+		select {
+		case <-s.close:
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+func (s *SSV) subscribeSSVEvents() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logs := make(chan ethtypes.Log, defaultLogBuf)
+	sub, err := s.client.SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{s.ssvNetworkAddr},
+	}, logs)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	ssvLog.Info("subscribed to SSV network events")
+	for {
+		select {
+		case <-s.close:
+			return nil
+		case err = <-sub.Err():
+			return err
+		case vLog := <-logs:
+			select {
+			case <-s.close:
+				return nil
+			case s.liveLogs <- vLog:
+			}
+		}
+	}
+}
+
+// handleLiveLog applies a subscribed log right away. The logs between the last applied block and the log are
+// fetched first, so the events are applied in order, logs applied already are skipped by processBlockEvents.
+// A log removed by a reorg rolls back the rows changed from its block on.
+func (s *SSV) handleLiveLog(vLog ethtypes.Log) error {
+	if vLog.BlockNumber <= s.lastProcessedBlock {
+		return nil
+	}
+
+	if vLog.Removed {
+		ssvLog.Infow("handleLiveLog: log removed by a reorg", "block", vLog.BlockNumber, "txHash", vLog.TxHash.Hex(), "logIndex", vLog.Index)
+		return s.rollbackLiveLogs(vLog.BlockNumber)
+	}
+
+	fromBlock := max(s.lastProcessedBlock, s.liveBlock) + 1
+	if vLog.BlockNumber > fromBlock {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fetchLogs, fetchError := s.fetchEvents(ctx, fromBlock, vLog.BlockNumber-1)
+		for blockLogs := range fetchLogs {
+			for _, gapLog := range blockLogs.Logs {
+				if err := s.applyLiveLog(gapLog); err != nil {
+					return err
+				}
+			}
+		}
+		if err := <-fetchError; err != nil {
+			return err
+		}
+	}
+
+	return s.applyLiveLog(vLog)
+}
+
+// applyLiveLog applies a log above the finalized block and records the rows it changed as provisional
+func (s *SSV) applyLiveLog(vLog ethtypes.Log) error {
+	event, ok := s.events[vLog.Topics[0]]
+	if !ok || s.store.TxHashLogIndexIsExist(vLog.TxHash.Hex(), vLog.Index) {
+		s.liveBlock = max(s.liveBlock, vLog.BlockNumber)
+		return nil
+	}
+
+	scopes, err := liveRowScopes(vLog, event)
+	if err != nil {
+		return err
+	}
+	before, err := s.store.SnapshotRows(scopes)
+	if err != nil {
+		return err
+	}
+
+	ssvLog.Infow("applyLiveLog", "event", event.Name, "block", vLog.BlockNumber, "txHash", vLog.TxHash.Hex(), "logIndex", vLog.Index)
+	processErr := s.processBlockEvents([]ethtypes.Log{vLog})
+	rows, err := s.store.CreateProvisionalRows(vLog.BlockNumber, vLog.BlockHash.Hex(), vLog.TxHash.Hex(), vLog.Index, scopes, before)
+	if err != nil {
+		return err
+	}
+	s.liveBlock = max(s.liveBlock, vLog.BlockNumber)
+	if processErr != nil {
+		// a partly applied log is rolled back, it is applied again with the next log or by the polling
+		if err = s.rollbackLiveLogs(vLog.BlockNumber); err != nil {
+			ssvLog.Errorw("applyLiveLog: rollbackLiveLogs", "block", vLog.BlockNumber, "err", err)
+		}
+		return processErr
+	}
+
+	ssvLog.Infow("applyLiveLog: provisional rows", "block", vLog.BlockNumber, "txHash", vLog.TxHash.Hex(), "rows", rows)
+	return nil
+}
+
+// rollbackLiveLogs restores the rows changed by the live logs from block on
+func (s *SSV) rollbackLiveLogs(block uint64) error {
+	rows, err := s.store.RollbackProvisionalRows(block)
+	if err != nil {
+		return err
+	}
+	if block <= s.liveBlock {
+		s.liveBlock = block - 1
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	ssvLog.Warnw("rollbackLiveLogs: rows restored", "fromBlock", block, "rows", rows)
+	if s.isSynced.Load() {
+		s.calcAllClusterLiquidationChan <- 0
+	}
+	return nil
+}
+
+// verifyProvisionalRows rolls back the live logs of blocks that are no longer canonical, e.g. when the
+// subscription missed the removed logs, and confirms the rows up to the finalized block toBlock
+func (s *SSV) verifyProvisionalRows(toBlock uint64) error {
+	blocks, err := s.store.GetProvisionalBlocks()
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	numbers := make([]uint64, 0, len(blocks))
+	for number := range blocks {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool {
+		return numbers[i] < numbers[j]
+	})
+	for _, number := range numbers {
+		header, err := s.client.HeaderByNumber(number)
+		if err != nil {
+			return err
+		}
+		if header.Hash().Hex() != blocks[number] {
+			ssvLog.Warnw("verifyProvisionalRows: block reorged", "block", number, "hash", blocks[number], "canonical", header.Hash().Hex())
+			if err = s.rollbackLiveLogs(number); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return s.store.ConfirmProvisionalRows(toBlock)
+}
+
+// liveRowScopes are the rows processBlockEvents may change for the log: the rows keyed by the log, the cluster and
+// its validators, the operators and the fee recipient of the owner named by the event, and the network info
+func liveRowScopes(vLog ethtypes.Log, event abi.Event) ([]store.RowScope, error) {
+	parties, err := getEventParties(vLog, event)
+	if err != nil {
+		return nil, err
+	}
+
+	var scopes []store.RowScope
+	for _, table := range []string{"event_infos", "operator_fee_infos", "network_param_infos", "cluster_ledger_infos", "fee_address_change_infos"} {
+		scopes = append(scopes, store.RowScope{Table: table, Where: "tx_hash = ? AND log_index = ?", Args: []interface{}{vLog.TxHash.Hex(), vLog.Index}})
+	}
+	scopes = append(scopes, store.RowScope{Table: "network_infos"})
+
+	operatorIds := parties.OperatorIds
+	if parties.OperatorId != 0 {
+		operatorIds = append(operatorIds, parties.OperatorId)
+	}
+	if len(operatorIds) > 0 {
+		scopes = append(scopes, store.RowScope{Table: "operator_infos", Where: "operator_id IN ?", Args: []interface{}{operatorIds}})
+	}
+	if parties.Owner != nil {
+		scopes = append(scopes, store.RowScope{Table: "fee_address_infos", Where: "owner = ?", Args: []interface{}{parties.Owner.String()}})
+		if len(parties.OperatorIds) > 0 {
+			clusterId := CalcClusterId(*parties.Owner, parties.OperatorIds)
+			scopes = append(scopes,
+				store.RowScope{Table: "cluster_infos", Where: "cluster_id = ?", Args: []interface{}{clusterId}},
+				store.RowScope{Table: "validator_infos", Where: "cluster_id = ?", Args: []interface{}{clusterId}})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,131 @@
+package ssv
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/monitorssv/monitorssv/store"
+	"math/big"
+)
+
+type eventParties struct {
+	Owner *common.Address
+	// OperatorId is set by the indexed operatorId of operator events
+	OperatorId uint64
+	// OperatorIds is set by the operatorIds in the data of cluster and whitelist events
+	OperatorIds []uint64
+}
+
+// scanUnsafeHead records the events between the finalized block and the head as provisional events.
+// The contract state is not touched, so a reorg above the finalized block only drops or replaces these events.
+func (s *SSV) scanUnsafeHead(fromBlock, toBlock uint64) error {
+	if fromBlock > toBlock {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetchLogs, fetchError := s.fetchEvents(ctx, fromBlock, toBlock)
+
+	var events []*store.EventInfo
+	for blockLogs := range fetchLogs {
+		for _, vLog := range blockLogs.Logs {
+			event, ok := s.events[vLog.Topics[0]]
+			if !ok {
+				continue
+			}
+
+			info, err := s.provisionalEvent(vLog, event)
+			if err != nil {
+				ssvLog.Warnw("scanUnsafeHead: provisionalEvent", "event", event.Name, "txHash", vLog.TxHash.Hex(), "err", err)
+				continue
+			}
+			events = append(events, info)
+		}
+	}
+
+	if err := <-fetchError; err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	ssvLog.Infow("scanUnsafeHead", "fromBlock", fromBlock, "toBlock", toBlock, "events", len(events))
+	return s.store.CreateEvent(events)
+}
+
+// provisionalEvent resolves the owner and cluster of an event the same way processBlockEvents records them
+func (s *SSV) provisionalEvent(vLog ethtypes.Log, event abi.Event) (*store.EventInfo, error) {
+	parties, err := getEventParties(vLog, event)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &store.EventInfo{
+		BlockNumber: vLog.BlockNumber,
+		Owner:       vLog.Address.String(),
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+		Action:      event.Name,
+		BlockHash:   vLog.BlockHash.Hex(),
+		Provisional: true,
+	}
+
+	if parties.Owner != nil {
+		info.Owner = parties.Owner.String()
+		if len(parties.OperatorIds) > 0 {
+			info.ClusterID = CalcClusterId(*parties.Owner, parties.OperatorIds)
+		}
+		return info, nil
+	}
+
+	operatorId := parties.OperatorId
+	if operatorId == 0 && len(parties.OperatorIds) > 0 {
+		operatorId = parties.OperatorIds[0]
+	}
+	if operatorId != 0 {
+		operator, err := s.store.GetOperatorByOperatorId(operatorId)
+		if err != nil {
+			return nil, err
+		}
+		// the operator may have been added above the finalized block as well
+		if operator != nil {
+			info.Owner = operator.Owner
+		}
+	}
+	return info, nil
+}
+
+// getEventParties reads the owner and operator ids of an event by the names of its inputs
+func getEventParties(vLog ethtypes.Log, event abi.Event) (eventParties, error) {
+	var parties eventParties
+	topic := 1
+	for _, input := range event.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if topic >= len(vLog.Topics) {
+			return parties, fmt.Errorf("missing topic of %s", input.Name)
+		}
+
+		switch input.Name {
+		case "owner":
+			owner := common.BytesToAddress(vLog.Topics[topic][12:])
+			parties.Owner = &owner
+		case "operatorId":
+			parties.OperatorId = big.NewInt(0).SetBytes(vLog.Topics[topic][:]).Uint64()
+		}
+		topic++
+	}
+
+	data := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(data, vLog.Data); err != nil {
+		return parties, err
+	}
+	parties.OperatorIds, _ = data["operatorIds"].([]uint64)
+	return parties, nil
+}
@@ -0,0 +1,63 @@
+package ssv
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestGetEventParties(t *testing.T) {
+	owner := common.HexToAddress("0x6A6C79d8dA4d3B1C8963073529CD026b36817eB6")
+	operatorIds := []uint64{1, 2, 3, 4}
+
+	exited := ssvABI.Events[ValidatorExited]
+	data, err := exited.Inputs.NonIndexed().Pack(operatorIds, []byte{0x01, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parties, err := getEventParties(ethtypes.Log{
+		Topics: []common.Hash{exited.ID, common.BytesToHash(owner.Bytes())},
+		Data:   data,
+	}, exited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parties.Owner == nil || *parties.Owner != owner {
+		t.Fatalf("expected owner %s, got %v", owner, parties.Owner)
+	}
+	if len(parties.OperatorIds) != len(operatorIds) || parties.OperatorIds[3] != 4 {
+		t.Fatalf("expected operatorIds %v, got %v", operatorIds, parties.OperatorIds)
+	}
+
+	liquidated := ssvABI.Events[ClusterLiquidated]
+	data, err = liquidated.Inputs.NonIndexed().Pack(operatorIds, ISSVNetworkCoreCluster{Active: false, Balance: big.NewInt(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parties, err = getEventParties(ethtypes.Log{
+		Topics: []common.Hash{liquidated.ID, common.BytesToHash(owner.Bytes())},
+		Data:   data,
+	}, liquidated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parties.Owner == nil || len(parties.OperatorIds) != len(operatorIds) {
+		t.Fatalf("expected owner and operatorIds, got %+v", parties)
+	}
+
+	removed := ssvABI.Events[OperatorRemoved]
+	parties, err = getEventParties(ethtypes.Log{
+		Topics: []common.Hash{removed.ID, common.BigToHash(big.NewInt(42))},
+	}, removed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parties.Owner != nil || parties.OperatorId != 42 || len(parties.OperatorIds) != 0 {
+		t.Fatalf("expected operator 42 without owner, got %+v", parties)
+	}
+
+	if _, err = getEventParties(ethtypes.Log{Topics: []common.Hash{removed.ID}}, removed); err == nil {
+		t.Fatal("expected error for missing topic")
+	}
+}
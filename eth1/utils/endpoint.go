@@ -0,0 +1,181 @@
+package utils
+
+import (
+	"fmt"
+	logging "github.com/ipfs/go-log/v2"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// an endpoint with maxFailures consecutive failures is skipped until failureCooldown passed
+	maxFailures     = 3
+	failureCooldown = time.Minute
+)
+
+var log = logging.Logger("endpoint-pool")
+
+// Endpoint tracks the health of a rpc endpoint, Client is what the endpoint is called with
+type Endpoint[T any] struct {
+	Url    string
+	Client T
+
+	mu          sync.Mutex
+	failures    int
+	lastFailure time.Time
+	lastErr     error
+	// latency is an exponential moving average of successful requests
+	latency time.Duration
+	head    uint64
+	lagging bool
+}
+
+func (e *Endpoint[T]) Success(latency time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures = 0
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = (e.latency*4 + latency) / 5
+	}
+}
+
+func (e *Endpoint[T]) Failure(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.failures++
+	e.lastFailure = time.Now()
+	e.lastErr = err
+}
+
+// SetHead records the chain head of the endpoint, a lagging endpoint is only used when the others fail
+func (e *Endpoint[T]) SetHead(head uint64, lagging bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.head = head
+	e.lagging = lagging
+}
+
+// score is lower for healthier endpoints
+func (e *Endpoint[T]) score() (bool, bool, int, time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	down := e.failures >= maxFailures && time.Since(e.lastFailure) < failureCooldown
+	return down, e.lagging, e.failures, e.latency
+}
+
+// EndpointPool orders the endpoints of a service by their health
+type EndpointPool[T any] struct {
+	name      string
+	endpoints []*Endpoint[T]
+}
+
+func NewEndpointPool[T any](name string) *EndpointPool[T] {
+	return &EndpointPool[T]{name: name}
+}
+
+func (p *EndpointPool[T]) Add(url string, client T) {
+	p.endpoints = append(p.endpoints, &Endpoint[T]{Url: url, Client: client})
+}
+
+func (p *EndpointPool[T]) Len() int {
+	return len(p.endpoints)
+}
+
+// Endpoints returns the endpoints in the configured order
+func (p *EndpointPool[T]) Endpoints() []*Endpoint[T] {
+	return p.endpoints
+}
+
+// Sorted orders the endpoints by health: endpoints in cooldown last, then lagging ones, then fewer failures, then lower latency
+func (p *EndpointPool[T]) Sorted() []*Endpoint[T] {
+	type scored struct {
+		ep       *Endpoint[T]
+		down     bool
+		lagging  bool
+		failures int
+		latency  time.Duration
+	}
+	list := make([]scored, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		down, lagging, failures, latency := ep.score()
+		list = append(list, scored{ep, down, lagging, failures, latency})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].down != list[j].down {
+			return !list[i].down
+		}
+		if list[i].lagging != list[j].lagging {
+			return !list[i].lagging
+		}
+		if list[i].failures != list[j].failures {
+			return list[i].failures < list[j].failures
+		}
+		return list[i].latency < list[j].latency
+	})
+
+	res := make([]*Endpoint[T], len(list))
+	for i := range list {
+		res[i] = list[i].ep
+	}
+	return res
+}
+
+// Best returns the healthiest endpoint, nil if there is none
+func (p *EndpointPool[T]) Best() *Endpoint[T] {
+	endpoints := p.Sorted()
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return endpoints[0]
+}
+
+type EndpointHealth struct {
+	Url      string `json:"url"`
+	Healthy  bool   `json:"healthy"`
+	Lagging  bool   `json:"lagging,omitempty"`
+	Head     uint64 `json:"head,omitempty"`
+	Failures int    `json:"failures"`
+	Latency  string `json:"latency"`
+	LastErr  string `json:"lastErr"`
+}
+
+// Health reports the state of every endpoint, ordered by preference
+func (p *EndpointPool[T]) Health() []EndpointHealth {
+	var res []EndpointHealth
+	for _, ep := range p.Sorted() {
+		down, lagging, failures, latency := ep.score()
+		h := EndpointHealth{
+			Url:      ep.Url,
+			Healthy:  !down,
+			Lagging:  lagging,
+			Failures: failures,
+			Latency:  latency.String(),
+		}
+		ep.mu.Lock()
+		h.Head = ep.head
+		if ep.lastErr != nil {
+			h.LastErr = ep.lastErr.Error()
+		}
+		ep.mu.Unlock()
+		res = append(res, h)
+	}
+	return res
+}
+
+// Failover calls the healthiest endpoint and fails over to the next one on error.
+// final tells the errors every endpoint answers the same way, they are returned without failover.
+func Failover[T, R any](p *EndpointPool[T], method string, final func(error) bool, call func(ep *Endpoint[T]) (R, error)) (R, error) {
+	var result R
+	err := fmt.Errorf("no %s endpoint configured", p.name)
+	for _, ep := range p.Sorted() {
+		result, err = call(ep)
+		if err == nil || final(err) {
+			return result, err
+		}
+		log.Warnw(p.name+" endpoint failed, try next", "endpoint", ep.Url, "method", method, "err", err)
+	}
+	return result, err
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEndpointPoolSorted(t *testing.T) {
+	pool := NewEndpointPool[struct{}]("test")
+	pool.Add("down", struct{}{})
+	pool.Add("lagging", struct{}{})
+	pool.Add("failing", struct{}{})
+	pool.Add("slow", struct{}{})
+	pool.Add("fast", struct{}{})
+
+	endpoints := pool.Endpoints()
+	for i := 0; i < maxFailures; i++ {
+		endpoints[0].Failure(errors.New("connection refused"))
+	}
+	endpoints[1].SetHead(100, true)
+	endpoints[2].Failure(errors.New("timeout"))
+	endpoints[3].Success(time.Second)
+	endpoints[4].Success(time.Millisecond)
+
+	expected := []string{"fast", "slow", "failing", "lagging", "down"}
+	for i, ep := range pool.Sorted() {
+		if ep.Url != expected[i] {
+			t.Fatalf("position %d: expected %s, got %s", i, expected[i], ep.Url)
+		}
+	}
+
+	health := pool.Health()
+	if health[4].Healthy || health[4].LastErr != "connection refused" {
+		t.Fatalf("expected the endpoint in cooldown to be unhealthy: %+v", health[4])
+	}
+}
+
+func TestFailover(t *testing.T) {
+	pool := NewEndpointPool[int]("test")
+	pool.Add("a", 1)
+	pool.Add("b", 2)
+
+	notFound := errors.New("not found")
+	final := func(err error) bool {
+		return errors.Is(err, notFound)
+	}
+
+	var called []string
+	res, err := Failover(pool, "call", final, func(ep *Endpoint[int]) (int, error) {
+		called = append(called, ep.Url)
+		if ep.Client == 1 {
+			return 0, errors.New("connection refused")
+		}
+		return ep.Client, nil
+	})
+	if err != nil || res != 2 || len(called) != 2 {
+		t.Fatalf("expected to fail over to b, got %d %v %v", res, err, called)
+	}
+
+	called = nil
+	_, err = Failover(pool, "call", final, func(ep *Endpoint[int]) (int, error) {
+		called = append(called, ep.Url)
+		return 0, notFound
+	})
+	if !errors.Is(err, notFound) || len(called) != 1 {
+		t.Fatalf("expected a final error without failover, got %v %v", err, called)
+	}
+
+	if _, err = Failover(NewEndpointPool[int]("test"), "call", final, func(ep *Endpoint[int]) (int, error) {
+		return 0, nil
+	}); err == nil {
+		t.Fatal("expected an error without endpoints")
+	}
+}
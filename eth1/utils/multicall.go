@@ -17,6 +17,8 @@ var (
 	mainnetMultiCallAddr = common.HexToAddress("0xeefba1e63905ef1d7acba5a8513c70307c1ce441")
 	// https://holesky.etherscan.io/address/0xa3e09ba95fa2887b351043841fe5d0cc33ff1052#contracts
 	holeskyMultiCallAddr = common.HexToAddress("0xA3e09ba95Fa2887b351043841Fe5d0CC33fF1052")
+	// https://hoodi.etherscan.io/address/0xcA11bde05977b3631167028862bE2a173976CA11#code (Multicall3, aggregate compatible)
+	hoodiMultiCallAddr = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -57,13 +59,16 @@ type MulticallCaller struct {
 }
 
 // NewMulticall creates a new instance of Multicall, bound to a specific deployed contract.
-func NewMulticall(network string, backend bind.ContractBackend) (*Multicall, error) {
+func NewMulticall(network string, caller bind.ContractCaller) (*Multicall, error) {
 	address := mainnetMultiCallAddr
-	if strings.ToLower(network) == "holesky" {
+	switch strings.ToLower(network) {
+	case "holesky":
 		address = holeskyMultiCallAddr
+	case "hoodi":
+		address = hoodiMultiCallAddr
 	}
 
-	contract, err := bindMulticall(address, backend, backend, backend)
+	contract, err := bindMulticall(address, caller, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -19,9 +19,22 @@ func toSSV(value *big.Int) *big.Float {
 	return new(big.Float).Quo(new(big.Float).Quo(big.NewFloat(0).SetInt(value), big.NewFloat(params.GWei)), big.NewFloat(params.GWei))
 }
 
+// ToETH formats an amount in wei as ETH, trailing zeros are trimmed
+func ToETH(value *big.Int, format string) string {
+	if value == nil {
+		return "0"
+	}
+	f := new(big.Float).Quo(new(big.Float).SetInt(value), big.NewFloat(params.Ether))
+	str := strings.TrimRight(fmt.Sprintf(format, f), "0")
+	str = strings.TrimRight(str, ".")
+	return str
+}
+
 type RetryConfig struct {
 	MaxRetries int
 	RetryDelay time.Duration
+	// Abort stops retrying on errors that will not go away, optional
+	Abort func(error) bool
 }
 
 var DefaultRetryConfig = RetryConfig{
@@ -38,6 +51,9 @@ func Retry[T any](operation func() (T, error), config RetryConfig) (T, error) {
 		if err == nil {
 			return result, nil
 		}
+		if config.Abort != nil && config.Abort(err) {
+			return result, err
+		}
 
 		if attempt < config.MaxRetries-1 {
 			time.Sleep(config.RetryDelay)
@@ -15,3 +15,16 @@ func TestToSSV(t *testing.T) {
 	f3 := ToSSV(big.NewInt(10000000000000000), "%.18f")
 	t.Log(f3)
 }
+
+func TestToETH(t *testing.T) {
+	value, _ := new(big.Int).SetString("1234500000000000000", 10)
+	if s := ToETH(value, "%.4f"); s != "1.2345" {
+		t.Fatalf("got %s", s)
+	}
+	if s := ToETH(big.NewInt(1e17), "%.4f"); s != "0.1" {
+		t.Fatalf("got %s", s)
+	}
+	if s := ToETH(nil, "%.4f"); s != "0" {
+		t.Fatalf("got %s", s)
+	}
+}
@@ -0,0 +1,155 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+)
+
+const (
+	// maxSeedLookahead is the delay between the activation of a validator and its activation epoch
+	maxSeedLookahead = 4
+	// the electra deposit churn, the same on all networks
+	minPerEpochChurnLimit               = 128000000000
+	maxPerEpochActivationExitChurnLimit = 256000000000
+	churnLimitQuotient                  = 65536
+	effectiveBalanceIncrement           = 1000000000
+	maxPendingDepositsPerEpoch          = 16
+)
+
+type queuedDeposit struct {
+	Pubkey string
+	Amount uint64
+}
+
+type activationEstimate struct {
+	Position uint64
+	Epoch    uint64
+}
+
+// ActivationEstimate times are unix seconds
+type ActivationEstimate struct {
+	QueuePosition   uint64 `json:"queuePosition"`
+	ActivationEpoch uint64 `json:"activationEpoch"`
+	ActivationTime  int64  `json:"activationTime"`
+}
+
+type pendingValidator struct {
+	info  *store.ValidatorInfo
+	entry *client.StandardValidatorEntry
+}
+
+// updateActivationQueue estimates the activation epoch of the pending SSV validators, and the deposit queue position
+// of the ones that are not in the beacon state yet
+func (bm *BeaconMonitor) updateActivationQueue(epoch uint64, pending []pendingValidator, resolved *validatorSet) error {
+	for _, p := range pending {
+		estimate := activationEstimate{Epoch: uint64(p.entry.Validator.ActivationEpoch)}
+		if estimate.Epoch == farFutureEpoch {
+			estimate.Epoch = registryActivationEpoch(uint64(p.entry.Validator.ActivationEligibilityEpoch), epoch)
+		}
+
+		if estimate.Position == p.info.ActivationQueuePosition && estimate.Epoch == p.info.ActivationEpoch {
+			continue
+		}
+		err := bm.store.UpdateValidatorActivation(p.info.PublicKey, estimate.Position, estimate.Epoch)
+		if err != nil {
+			log.Errorw("UpdateValidatorActivation", "err", err)
+			return err
+		}
+	}
+
+	// the churn comes from the streamed validator set, without it the deposit queue is not estimated
+	if resolved == nil || len(resolved.missing) == 0 {
+		return nil
+	}
+
+	deposits, err := bm.client.GetPendingDeposits(bm.profile.EpochLastSlot(epoch))
+	if err != nil {
+		log.Warnw("GetPendingDeposits", "err", err)
+		return err
+	}
+	queue := make([]queuedDeposit, 0, len(deposits))
+	for _, deposit := range deposits {
+		queue = append(queue, queuedDeposit{Pubkey: deposit.Pubkey, Amount: uint64(deposit.Amount)})
+	}
+	churn := activationChurnLimit(resolved.totalActiveBalance)
+	estimates := estimateDepositActivations(queue, epoch, churn)
+	log.Infow("updateActivationQueue", "epoch", epoch, "deposits", len(queue), "churn", churn)
+
+	for pubKey := range resolved.missing {
+		estimate, ok := estimates[pubKey]
+		if !ok {
+			continue
+		}
+		err = bm.store.UpdateValidatorActivation(removePubKeyPrefix(pubKey), estimate.Position, estimate.Epoch)
+		if err != nil {
+			log.Errorw("UpdateValidatorActivation", "err", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// activationChurnLimit is the balance in gwei the deposit queue may consume per epoch
+func activationChurnLimit(totalActiveBalance uint64) uint64 {
+	churn := max(minPerEpochChurnLimit, totalActiveBalance/churnLimitQuotient)
+	churn -= churn % effectiveBalanceIncrement
+	return min(maxPerEpochActivationExitChurnLimit, churn)
+}
+
+// estimateDepositActivations processes the deposit queue like the epoch transition, spending the churn and at most
+// maxPendingDepositsPerEpoch deposits per epoch. The churn left over by a deposit that did not fit is carried to
+// the next epoch. Only the first deposit of a public key creates the validator, it sets the estimate.
+func estimateDepositActivations(queue []queuedDeposit, epoch, churn uint64) map[string]activationEstimate {
+	res := make(map[string]activationEstimate)
+	if churn == 0 {
+		return res
+	}
+
+	processEpoch := epoch
+	available, processed, count := churn, uint64(0), 0
+	for i, deposit := range queue {
+		for count >= maxPendingDepositsPerEpoch || processed+deposit.Amount > available {
+			if processed+deposit.Amount > available {
+				available = available - processed + churn
+			} else {
+				available = churn
+			}
+			processed, count = 0, 0
+			processEpoch++
+		}
+		processed += deposit.Amount
+		count++
+
+		if _, ok := res[deposit.Pubkey]; !ok {
+			// the validator is added at the end of processEpoch and becomes eligible in the next epoch transition
+			res[deposit.Pubkey] = activationEstimate{
+				Position: uint64(i + 1),
+				Epoch:    registryActivationEpoch(processEpoch+2, processEpoch+1),
+			}
+		}
+	}
+	return res
+}
+
+// registryActivationEpoch is the activation epoch of a validator in the beacon state, assuming finality two epochs
+// behind: it is activated once its eligibility epoch is finalized, maxSeedLookahead epochs ahead
+func registryActivationEpoch(eligibilityEpoch, epoch uint64) uint64 {
+	if eligibilityEpoch == farFutureEpoch {
+		// set in the transition at the end of epoch
+		eligibilityEpoch = epoch + 1
+	}
+	return max(eligibilityEpoch+1, epoch) + 1 + maxSeedLookahead
+}
+
+// ActivationEstimate returns nil unless the validator is pending or in the deposit queue with a known activation epoch
+func (bm *BeaconMonitor) ActivationEstimate(v *store.ValidatorInfo) *ActivationEstimate {
+	if (v.Status != store.ValidatorPending && v.Status != store.ValidatorUnknown) || v.ActivationEpoch == 0 {
+		return nil
+	}
+	return &ActivationEstimate{
+		QueuePosition:   v.ActivationQueuePosition,
+		ActivationEpoch: v.ActivationEpoch,
+		ActivationTime:  bm.profile.EpochTime(v.ActivationEpoch).Unix(),
+	}
+}
@@ -0,0 +1,69 @@
+package eth2
+
+import "testing"
+
+func TestActivationChurnLimit(t *testing.T) {
+	tests := []struct {
+		totalActiveBalance uint64
+		expected           uint64
+	}{
+		{totalActiveBalance: 1000000000000000, expected: minPerEpochChurnLimit},
+		// 10M ETH is 152.58 ETH, rounded down to whole ETH
+		{totalActiveBalance: 10000000000000000, expected: 152000000000},
+		{totalActiveBalance: 34000000000000000, expected: maxPerEpochActivationExitChurnLimit},
+	}
+	for _, test := range tests {
+		if churn := activationChurnLimit(test.totalActiveBalance); churn != test.expected {
+			t.Fatalf("total %d: expected %d, got %d", test.totalActiveBalance, test.expected, churn)
+		}
+	}
+}
+
+func TestEstimateDepositActivations(t *testing.T) {
+	queue := []queuedDeposit{
+		{Pubkey: "0x01", Amount: 32000000000},
+		{Pubkey: "0x02", Amount: 32000000000},
+		// top-up of 0x01 does not move its estimate
+		{Pubkey: "0x01", Amount: 1000000000},
+		{Pubkey: "0x03", Amount: 64000000000},
+		{Pubkey: "0x04", Amount: 32000000000},
+	}
+
+	// 64 ETH per epoch: the top-up moves to epoch 101, 0x03 does not fit next to it and is processed in 102
+	// with the 63 ETH carried over, which leaves room for 0x04
+	estimates := estimateDepositActivations(queue, 100, 64000000000)
+	expected := map[string]activationEstimate{
+		"0x01": {Position: 1, Epoch: 108},
+		"0x02": {Position: 2, Epoch: 108},
+		"0x03": {Position: 4, Epoch: 110},
+		"0x04": {Position: 5, Epoch: 110},
+	}
+	if len(estimates) != len(expected) {
+		t.Fatalf("expected %d estimates, got %v", len(expected), estimates)
+	}
+	for pubKey, estimate := range expected {
+		if estimates[pubKey] != estimate {
+			t.Fatalf("%s: expected %v, got %v", pubKey, estimate, estimates[pubKey])
+		}
+	}
+
+	// at most 16 deposits per epoch, however small they are
+	small := make([]queuedDeposit, 17)
+	for i := range small {
+		small[i] = queuedDeposit{Pubkey: string(rune('a' + i)), Amount: 1000000000}
+	}
+	estimates = estimateDepositActivations(small, 100, 256000000000)
+	if estimates["a"].Epoch != 108 || estimates["q"].Epoch != 109 {
+		t.Fatalf("unexpected estimates %v", estimates)
+	}
+}
+
+func TestRegistryActivationEpoch(t *testing.T) {
+	// eligibility set at the end of epoch 100, finalized at the end of 102
+	if epoch := registryActivationEpoch(farFutureEpoch, 100); epoch != 107 {
+		t.Fatalf("expected 107, got %d", epoch)
+	}
+	if epoch := registryActivationEpoch(90, 100); epoch != 105 {
+		t.Fatalf("expected 105, got %d", epoch)
+	}
+}
@@ -0,0 +1,94 @@
+package eth2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// backfillChunkEpochs is the number of epochs fetched before the gaps are counted and the progress is logged
+const backfillChunkEpochs = 200
+
+// BackfillReport counts the proposals recorded by the backfill and lists the ssv proposal slots still missing
+type BackfillReport struct {
+	StartEpoch uint64
+	EndEpoch   uint64
+	// Proposals is the number of ssv proposals recorded in the range after the backfill
+	Proposals int
+	// Filled is the number of proposals the backfill recorded
+	Filled int
+	// Gaps are the ssv proposal slots that could not be recorded
+	Gaps []uint64
+}
+
+// Backfill scans the proposals of [startEpoch, endEpoch] again. It runs next to the live monitor:
+// blocks that are already recorded are skipped, and the scan point is not moved.
+func (bm *BeaconMonitor) Backfill(ctx context.Context, startEpoch, endEpoch uint64, parallel int) (*BackfillReport, error) {
+	if endEpoch < startEpoch {
+		return nil, fmt.Errorf("invalid epoch range %d-%d", startEpoch, endEpoch)
+	}
+	// a backfill monitor has no alarm, nothing is sent to the nil alarm channels as long as it never syncs
+	if bm.isSynced.Load() {
+		return nil, errors.New("backfill must not run on a started monitor")
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	report := &BackfillReport{StartEpoch: startEpoch, EndEpoch: endEpoch}
+	for fromEpoch := startEpoch; fromEpoch <= endEpoch; fromEpoch += backfillChunkEpochs {
+		toEpoch := min(fromEpoch+backfillChunkEpochs-1, endEpoch)
+		fromSlot, toSlot := bm.profile.EpochStartSlot(fromEpoch), bm.profile.EpochLastSlot(toEpoch)
+
+		before, err := bm.store.GetBlockSlots(fromSlot, toSlot)
+		if err != nil {
+			log.Errorw("GetBlockSlots", "err", err)
+			return report, err
+		}
+
+		fetchBlocks, fetchErrors := bm.fetchBeaconBlocks(ctx, fromEpoch, toEpoch, parallel)
+		_, failed, err := bm.handleBlocks(fetchBlocks)
+		if err != nil {
+			return report, err
+		}
+		if err = <-fetchErrors; err != nil {
+			return report, err
+		}
+
+		after, err := bm.store.GetBlockSlots(fromSlot, toSlot)
+		if err != nil {
+			log.Errorw("GetBlockSlots", "err", err)
+			return report, err
+		}
+
+		// the live monitor may have recorded a failed slot meanwhile
+		sort.Slice(failed, func(i, j int) bool {
+			return failed[i] < failed[j]
+		})
+		gaps := missingSlots(after, failed)
+		filled := len(missingSlots(before, after))
+		report.Proposals += len(after)
+		report.Filled += filled
+		report.Gaps = append(report.Gaps, gaps...)
+		log.Infow("Backfill", "fromEpoch", fromEpoch, "toEpoch", toEpoch, "proposals", len(after), "filled", filled, "gaps", len(gaps))
+	}
+
+	return report, nil
+}
+
+// missingSlots returns the slots of after which are not in before, both are sorted
+func missingSlots(before, after []uint64) []uint64 {
+	var res []uint64
+	i := 0
+	for _, slot := range after {
+		for i < len(before) && before[i] < slot {
+			i++
+		}
+		if i < len(before) && before[i] == slot {
+			continue
+		}
+		res = append(res, slot)
+	}
+	return res
+}
@@ -0,0 +1,24 @@
+package eth2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingSlots(t *testing.T) {
+	tests := []struct {
+		before   []uint64
+		after    []uint64
+		expected []uint64
+	}{
+		{before: nil, after: []uint64{1, 2}, expected: []uint64{1, 2}},
+		{before: []uint64{1, 2}, after: []uint64{1, 2}, expected: nil},
+		{before: []uint64{2, 5}, after: []uint64{1, 2, 3, 5, 8}, expected: []uint64{1, 3, 8}},
+	}
+	for _, test := range tests {
+		gaps := missingSlots(test.before, test.after)
+		if !reflect.DeepEqual(gaps, test.expected) {
+			t.Fatalf("expected %v, got %v", test.expected, gaps)
+		}
+	}
+}
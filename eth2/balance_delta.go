@@ -17,13 +17,23 @@ type Balance struct {
 }
 
 func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
-	slot := (epoch+1)*32 - 1
+	slot := bm.profile.EpochLastSlot(epoch)
 	itemsPerPage := 1000
 	page := 1
 
 	validatorInfoMap := make(map[string]*client.StandardValidatorEntry)
 	clusterBalanceAlarms := make(map[string][]uint64)
 	clusterSlashAlarms := make(map[string][]uint64)
+	clusterActivatedAlarms := make(map[string][]uint64)
+	swept := make(map[uint64]*store.ValidatorInfo)
+	var pending []pendingValidator
+	clusterIndices := make(map[string][]uint64)
+
+	// the full validator set replaces the chunked queries below, they are only the fallback
+	resolved, err := bm.resolveValidators(slot)
+	if err != nil {
+		log.Warnw("resolveValidators: fall back to chunked validator queries", "err", err)
+	}
 
 	for {
 		validators, totalCount, err := bm.store.AdminGetValidators(page, itemsPerPage)
@@ -37,11 +47,15 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 
 		var pubKeys []string
 		var indexs []uint64
+		var decisions []uint64
+		decisionKeys := make(map[uint64]string)
+		statuses := make(map[string]string)
 		validatorMap := make(map[string]*store.ValidatorInfo)
 
 		for i := range validators {
 			v := &validators[i]
-			if v.IsSlashed || (v.Status != store.ValidatorActive && v.ExitedBlock != 0) {
+			// exited and slashed validators are only followed until their balance is swept
+			if v.WithdrawnSlot != 0 {
 				continue
 			}
 
@@ -55,6 +69,15 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 			}
 		}
 
+		if resolved != nil {
+			for pubKey := range validatorMap {
+				if info, ok := resolved.entries[pubKey]; ok {
+					validatorInfoMap[pubKey] = info
+				}
+			}
+			pubKeys, indexs = nil, nil
+		}
+
 		if len(pubKeys) > 0 {
 			log.Infow("GetSlotValidatorsByPubKey", "pubKeys", len(pubKeys))
 			validatorInfoMap1, err := bm.client.GetSlotValidatorsByPubKey(slot, pubKeys)
@@ -112,55 +135,130 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 				continue
 			}
 
-			bm.updateBalanceHistory(uint64(validatorInfo.Index), epoch, uint64(validatorInfo.Balance))
-			if isAlarm := bm.checkBalanceChange(uint64(validatorInfo.Index), v.IsOnline); isAlarm {
-				clusterBalanceAlarms[v.ClusterID] = append(clusterBalanceAlarms[v.ClusterID], uint64(validatorInfo.Index))
+			settled := v.IsSlashed || (v.Status != store.ValidatorActive && v.ExitedBlock != 0)
+			if !settled {
+				bm.updateBalanceHistory(uint64(validatorInfo.Index), epoch, uint64(validatorInfo.Balance))
+				if isAlarm := bm.checkBalanceChange(uint64(validatorInfo.Index)); isAlarm {
+					clusterBalanceAlarms[v.ClusterID] = append(clusterBalanceAlarms[v.ClusterID], uint64(validatorInfo.Index))
+				}
+			}
+
+			switch store.GetStatusDescription(validatorInfo.Status) {
+			case store.ValidatorPending:
+				pending = append(pending, pendingValidator{info: v, entry: validatorInfo})
+			case store.ValidatorActive:
+				if !settled {
+					clusterIndices[v.ClusterID] = append(clusterIndices[v.ClusterID], uint64(validatorInfo.Index))
+				}
+				if v.Status == store.ValidatorPending {
+					clusterActivatedAlarms[v.ClusterID] = append(clusterActivatedAlarms[v.ClusterID], uint64(validatorInfo.Index))
+					err = bm.store.UpdateValidatorActivation(v.PublicKey, 0, uint64(validatorInfo.Validator.ActivationEpoch))
+					if err != nil {
+						log.Errorw("UpdateValidatorActivation", "err", err)
+						return err
+					}
+				}
 			}
 
 			if v.Status != store.GetStatusDescription(validatorInfo.Status) {
 				log.Infow("UpdateValidatorStatus", "pubKey", validatorInfo.Validator.Pubkey, "status", validatorInfo.Status)
-				err = bm.store.UpdateValidatorStatus(removePubKeyPrefix(validatorInfo.Validator.Pubkey), store.GetStatusDescription(validatorInfo.Status))
-				if err != nil {
-					log.Errorw("UpdateValidatorStatus", "err", err)
-					return err
+				statuses[removePubKeyPrefix(validatorInfo.Validator.Pubkey)] = store.GetStatusDescription(validatorInfo.Status)
+			}
+
+			// exits and slashings are tracked from the confirmed entry below
+			if !settled && (store.GetStatusDescription(validatorInfo.Status) == store.ValidatorExited || validatorInfo.Validator.Slashed) {
+				decisions = append(decisions, uint64(validatorInfo.Index))
+				decisionKeys[uint64(validatorInfo.Index)] = validatorInfo.Validator.Pubkey
+				continue
+			}
+
+			err = bm.trackExit(v, validatorInfo, swept)
+			if err != nil {
+				log.Errorw("trackExit", "err", err)
+				return err
+			}
+		}
+
+		if len(decisions) > 0 {
+			// exits and slashings are permanent records, cross-check them with the other beacon nodes
+			confirmed, err := bm.client.ConfirmValidatorsByIndex(slot, decisions)
+			if err != nil {
+				// the validators stay unsettled, so the decisions are retried on the next epoch
+				log.Warnw("ConfirmValidatorsByIndex: skip unconfirmed exits and slashings", "epoch", epoch, "validators", len(decisions), "err", err)
+				confirmed = nil
+			}
+			for _, pubKey := range decisionKeys {
+				if _, ok := confirmed[pubKey]; !ok {
+					delete(statuses, removePubKeyPrefix(pubKey))
 				}
 			}
 
-			if store.GetStatusDescription(validatorInfo.Status) == store.ValidatorExited {
-				err = bm.store.UpdateValidatorOnlineStatus(int64(validatorInfo.Index), false)
-				if err != nil {
-					log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", validatorInfo.Index, "online", false)
-					return err
+			for _, validatorInfo := range confirmed {
+				v := validatorMap[validatorInfo.Validator.Pubkey]
+				if v == nil {
+					continue
 				}
 
-				exitEpoch := epoch
-				if validatorInfo.Validator.ExitEpoch != 0 {
-					exitEpoch = uint64(validatorInfo.Validator.ExitEpoch)
+				// the quorum may disagree with the status the primary node reported
+				pubKey := removePubKeyPrefix(validatorInfo.Validator.Pubkey)
+				if status := store.GetStatusDescription(validatorInfo.Status); status != v.Status {
+					statuses[pubKey] = status
 				} else {
-					log.Warnw("ExitValidator", "validatorIndex", validatorInfo.Index, "status", validatorInfo.Status, "validatorInfo.Validator.ExitEpoch", 0)
+					delete(statuses, pubKey)
 				}
 
-				blockNumber, err := bm.getEth1ExBlock(exitEpoch * 32)
+				err = bm.trackExit(v, validatorInfo, swept)
 				if err != nil {
-					log.Errorw("GetEth1ExBlock", "err", err)
+					log.Errorw("trackExit", "err", err)
 					return err
 				}
-				err = bm.store.ExitValidator(removePubKeyPrefix(validatorInfo.Validator.Pubkey), int64(blockNumber))
-				if err != nil {
-					log.Errorw("ExitValidator", "err", err)
-					return err
-				}
-			}
 
-			if validatorInfo.Validator.Slashed {
-				clusterSlashAlarms[v.ClusterID] = append(clusterSlashAlarms[v.ClusterID], uint64(validatorInfo.Index))
-				log.Infow("ValidatorSlash", "pubKey", validatorInfo.Validator.Pubkey)
+				if store.GetStatusDescription(validatorInfo.Status) == store.ValidatorExited {
+					err = bm.store.UpdateValidatorOnlineStatus(int64(validatorInfo.Index), false)
+					if err != nil {
+						log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", validatorInfo.Index, "online", false)
+						return err
+					}
 
-				err = bm.store.ValidatorSlash(removePubKeyPrefix(validatorInfo.Validator.Pubkey))
-				if err != nil {
-					log.Errorw("ValidatorSlash", "err", err)
-					return err
+					exitEpoch := epoch
+					if validatorInfo.Validator.ExitEpoch != 0 {
+						exitEpoch = uint64(validatorInfo.Validator.ExitEpoch)
+					} else {
+						log.Warnw("ExitValidator", "validatorIndex", validatorInfo.Index, "status", validatorInfo.Status, "validatorInfo.Validator.ExitEpoch", 0)
+					}
+
+					blockNumber, err := bm.getEth1ExBlock(bm.profile.EpochStartSlot(exitEpoch))
+					if err != nil {
+						log.Errorw("GetEth1ExBlock", "err", err)
+						return err
+					}
+					err = bm.store.ExitValidator(removePubKeyPrefix(validatorInfo.Validator.Pubkey), int64(blockNumber))
+					if err != nil {
+						log.Errorw("ExitValidator", "err", err)
+						return err
+					}
 				}
+
+				if validatorInfo.Validator.Slashed {
+					log.Infow("ValidatorSlash", "pubKey", validatorInfo.Validator.Pubkey)
+					marked, err := bm.store.ValidatorSlash(removePubKeyPrefix(validatorInfo.Validator.Pubkey))
+					if err != nil {
+						log.Errorw("ValidatorSlash", "err", err)
+						return err
+					}
+					// checkSlashings may have recorded and alerted it from the slashing operation already
+					if marked {
+						clusterSlashAlarms[v.ClusterID] = append(clusterSlashAlarms[v.ClusterID], uint64(validatorInfo.Index))
+					}
+				}
+			}
+		}
+
+		if len(statuses) > 0 {
+			err = bm.store.BatchUpdateValidatorStatus(epoch, statuses)
+			if err != nil {
+				log.Errorw("BatchUpdateValidatorStatus", "err", err)
+				return err
 			}
 		}
 
@@ -170,6 +268,20 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 		page++
 	}
 
+	bm.updateSweepPosition(epoch)
+	// the statuses are already written, so the alerts below would be lost for good, the validators
+	// not marked withdrawn stay swept and are searched again on the next epoch
+	err = bm.handleSweptValidators(epoch, swept)
+	if err != nil {
+		log.Warnw("handleSweptValidators", "err", err)
+	}
+
+	// the estimates are refreshed on the next epoch, a failure must not hold back the alerts below
+	err = bm.updateActivationQueue(epoch, pending, resolved)
+	if err != nil {
+		log.Warnw("updateActivationQueue", "err", err)
+	}
+
 	for clusterId, balanceAlarms := range clusterBalanceAlarms {
 		if len(balanceAlarms) > 0 {
 			bm.validatorBalanceDeltaAlarmChan <- alert.ValidatorBalanceDeltaNotify{
@@ -180,6 +292,14 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 		}
 	}
 
+	for clusterId, activatedAlarms := range clusterActivatedAlarms {
+		bm.validatorActivatedAlarmChan <- alert.ValidatorActivatedNotify{
+			Epoch:     epoch,
+			ClusterId: clusterId,
+			Index:     activatedAlarms,
+		}
+	}
+
 	for clusterId, slashAlarms := range clusterSlashAlarms {
 		if len(slashAlarms) > 0 {
 			bm.validatorSlashAlarmChan <- alert.ValidatorSlashNotify{
@@ -190,6 +310,13 @@ func (bm *BeaconMonitor) validatorMonitor(epoch uint64) error {
 		}
 	}
 
+	// attestation rewards are only available for epochs that are at least 2 epochs old
+	err = bm.collectDuties(epoch-1, clusterIndices)
+	if err != nil {
+		log.Errorw("collectDuties", "epoch", epoch-1, "err", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -212,31 +339,15 @@ func (bm *BeaconMonitor) updateBalanceHistory(validatorIndex, epoch, balance uin
 	bm.validatorBalanceHistory[validatorIndex] = history
 }
 
-func (bm *BeaconMonitor) checkBalanceChange(validatorIndex uint64, isOnline bool) bool {
+// checkBalanceChange reports a validator whose balance decreased in the last two epochs. The online status is
+// maintained by the offline detector from the missed duties.
+func (bm *BeaconMonitor) checkBalanceChange(validatorIndex uint64) bool {
 	history := bm.validatorBalanceHistory[validatorIndex]
 	if history[0].Epoch == 0 || history[1].Epoch == 0 || history[2].Epoch == 0 {
 		return false
 	}
 
-	if history[0].Amount > history[1].Amount {
-		if !isOnline {
-			log.Infow("checkBalanceChange: UpdateValidatorOnlineStatus", "validatorIndex", validatorIndex, "online", true)
-			err := bm.store.UpdateValidatorOnlineStatus(int64(validatorIndex), true)
-			if err != nil {
-				log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", validatorIndex, "online", true)
-			}
-		}
-	}
-
 	if history[0].Amount != EffectiveBalance && history[0].Amount < history[1].Amount && history[1].Amount < history[2].Amount {
-		if isOnline {
-			log.Infow("checkBalanceChange: UpdateValidatorOnlineStatus", "validatorIndex", validatorIndex, "online", false)
-			err := bm.store.UpdateValidatorOnlineStatus(int64(validatorIndex), false)
-			if err != nil {
-				log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", validatorIndex, "online", false)
-			}
-		}
-
 		log.Infow("Validator balance decrease", "validatorIndex", validatorIndex, "curBalance", history[0].Amount, "preBalance", history[1].Amount, "epoch", history[0].Epoch)
 		return true
 	}
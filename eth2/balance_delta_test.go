@@ -14,7 +14,7 @@ func TestCheckBalanceChange(t *testing.T) {
 	bm := initBeaconMonitor(t)
 	bm.updateBalanceHistory(1, 1, 100)
 	t.Log(bm.validatorBalanceHistory)
-	t.Log(bm.checkBalanceChange(1, true))
+	t.Log(bm.checkBalanceChange(1))
 	bm.updateBalanceHistory(1, 2, 101)
 	t.Log(bm.validatorBalanceHistory)
 	bm.updateBalanceHistory(1, 3, 102)
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -9,14 +10,19 @@ import (
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 )
 
 type Client struct {
-	httpClient *http.Client
-	endpoint   string
+	httpClient   *http.Client
+	streamClient *http.Client
+
+	endpoints *utils.EndpointPool[struct{}]
+	// quorum is the number of endpoints that must agree on critical reads
+	quorum int
 }
 
 const (
@@ -26,13 +32,34 @@ const (
 
 var log = logging.Logger("monitor-service")
 
-func NewClient(endpoint string) *Client {
-	return &Client{
-		endpoint: endpoint,
+// NewClient creates a client which fails over between the given beacon endpoints
+func NewClient(endpoints ...string) *Client {
+	c := &Client{
 		httpClient: &http.Client{
 			Timeout: 1 * time.Minute,
 		},
+		streamClient: &http.Client{},
+		endpoints:    utils.NewEndpointPool[struct{}]("beacon"),
+		quorum:       1,
+	}
+	for _, url := range endpoints {
+		if url == "" {
+			continue
+		}
+		c.endpoints.Add(strings.TrimSuffix(url, "/"), struct{}{})
+	}
+	return c
+}
+
+// SetQuorum sets how many endpoints have to return the same finality checkpoint or validator status
+func (c *Client) SetQuorum(quorum int) {
+	if quorum < 1 {
+		quorum = 1
 	}
+	if quorum > c.endpoints.Len() {
+		quorum = c.endpoints.Len()
+	}
+	c.quorum = quorum
 }
 
 type bytesHexStr []byte
@@ -77,6 +104,20 @@ func Uint64Unmarshal(v *uint64, b []byte) error {
 	return nil
 }
 
+type int64Str int64
+
+func (s *int64Str) UnmarshalJSON(b []byte) error {
+	if len(b) > 1 && (b[0] == '"' || b[0] == '\'') {
+		b = b[1 : len(b)-1]
+	}
+	n, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	*s = int64Str(n)
+	return nil
+}
+
 type StandardProposerDutiesResponse struct {
 	DependentRoot string                 `json:"dependent_root"`
 	Data          []StandardProposerDuty `json:"data"`
@@ -90,7 +131,7 @@ type StandardProposerDuty struct {
 
 func (c *Client) GetEpochProposer(epoch uint64) (*StandardProposerDutiesResponse, error) {
 	return utils.Retry(func() (*StandardProposerDutiesResponse, error) {
-		proposerResp, err := c.get(fmt.Sprintf("%s/eth/v1/validator/duties/proposer/%d", c.endpoint, epoch))
+		proposerResp, err := c.get(fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch))
 		if err != nil {
 			log.Warnf("error retrieving proposer duties for epoch %v: %s", epoch, err)
 			return nil, err
@@ -125,7 +166,7 @@ type StandardBeaconHeaderResponse struct {
 
 func (c *Client) GetSlotHeader(slot uint64) (*StandardBeaconHeaderResponse, error) {
 	return utils.Retry(func() (*StandardBeaconHeaderResponse, error) {
-		resHeaders, err := c.get(fmt.Sprintf("%s/eth/v1/beacon/headers/%d", c.endpoint, slot))
+		resHeaders, err := c.get(fmt.Sprintf("/eth/v1/beacon/headers/%d", slot))
 		if err != nil {
 			log.Warnf("error retrieving headers at slot %v: %s", slot, err)
 			return nil, err
@@ -141,7 +182,7 @@ func (c *Client) GetSlotHeader(slot uint64) (*StandardBeaconHeaderResponse, erro
 
 func (c *Client) GetLatestSlot() (uint64, error) {
 	return utils.Retry(func() (uint64, error) {
-		resHeaders, err := c.get(fmt.Sprintf("%s/eth/v1/beacon/headers/head", c.endpoint))
+		resHeaders, err := c.get("/eth/v1/beacon/headers/head")
 		if err != nil {
 			log.Warnf("error retrieving headers: %s", err)
 			return 0, err
@@ -184,7 +225,7 @@ func (c *Client) GetSlotValidatorsByPubKey(slot uint64, validatorPubKeys []strin
 			return c.chunkedValidatorsByPubKey(slot, validatorPubKeys)
 		}
 
-		validatorsResp, err := c.get(fmt.Sprintf("%s/eth/v1/beacon/states/%d/validators?id=%s", c.endpoint, slot, strings.Join(validatorPubKeys, ",")))
+		validatorsResp, err := c.get(fmt.Sprintf("/eth/v1/beacon/states/%d/validators?id=%s", slot, strings.Join(validatorPubKeys, ",")))
 		if err != nil {
 			log.Warnf("error retrieving validators for slot %v: %s", slot, err)
 			return nil, err
@@ -235,7 +276,7 @@ func (c *Client) GetSlotValidatorsByIndex(slot uint64, validatorIndices []uint64
 			return c.chunkedValidatorsByIndex(slot, validatorIndices)
 		}
 
-		validatorsResp, err := c.get(fmt.Sprintf("%s/eth/v1/beacon/states/%d/validators?id=%s", c.endpoint, slot, joinUint64(validatorIndices, ",")))
+		validatorsResp, err := c.get(fmt.Sprintf("/eth/v1/beacon/states/%d/validators?id=%s", slot, joinUint64(validatorIndices, ",")))
 		if err != nil {
 			log.Warnf("error retrieving validators for slot %v: %s", slot, err)
 			return nil, err
@@ -256,6 +297,36 @@ func (c *Client) GetSlotValidatorsByIndex(slot uint64, validatorIndices []uint64
 	}, utils.DefaultRetryConfig)
 }
 
+// PendingDeposit is a deposit waiting in the beacon state for the balance churn, amount in gwei
+type PendingDeposit struct {
+	Pubkey                string    `json:"pubkey"`
+	WithdrawalCredentials string    `json:"withdrawal_credentials"`
+	Amount                uint64Str `json:"amount"`
+	Slot                  uint64Str `json:"slot"`
+}
+
+type StandardPendingDepositsResponse struct {
+	Data []PendingDeposit `json:"data"`
+}
+
+// GetPendingDeposits returns the deposit queue at slot in processing order, it exists since electra
+func (c *Client) GetPendingDeposits(slot uint64) ([]PendingDeposit, error) {
+	return utils.Retry(func() ([]PendingDeposit, error) {
+		depositsResp, err := c.get(fmt.Sprintf("/eth/v1/beacon/states/%d/pending_deposits", slot))
+		if err != nil {
+			log.Warnf("error retrieving pending deposits for slot %v: %s", slot, err)
+			return nil, err
+		}
+
+		parsedDeposits := &StandardPendingDepositsResponse{}
+		err = json.Unmarshal(depositsResp, parsedDeposits)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing pending deposits: %s", err)
+		}
+		return parsedDeposits.Data, nil
+	}, utils.DefaultRetryConfig)
+}
+
 func (c *Client) chunkedValidatorsByIndex(slot uint64, validatorIndices []uint64) (map[string]*StandardValidatorEntry, error) {
 	res := make(map[string]*StandardValidatorEntry)
 	for i := 0; i < len(validatorIndices); i += defaultIndexChunkSize {
@@ -304,7 +375,7 @@ type StandardFinalityCheckpointsResponse struct {
 
 func (c *Client) GetFinalizedEpoch() (uint64, error) {
 	return utils.Retry(func() (uint64, error) {
-		finalityResp, err := c.get(fmt.Sprintf("%s/eth/v1/beacon/states/%s/finality_checkpoints", c.endpoint, "head"))
+		finalityResp, err := c.getQuorum(fmt.Sprintf("/eth/v1/beacon/states/%s/finality_checkpoints", "head"), finalityKey)
 		if err != nil {
 			log.Warnf("error retrieving finality checkpoints of head: %s", err)
 			return 0, err
@@ -324,6 +395,66 @@ func (c *Client) GetFinalizedEpoch() (uint64, error) {
 	}, utils.DefaultRetryConfig)
 }
 
+func finalityKey(data []byte) (string, error) {
+	var parsedFinality StandardFinalityCheckpointsResponse
+	err := json.Unmarshal(data, &parsedFinality)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d:%s", parsedFinality.Data.Finalized.Epoch, parsedFinality.Data.Finalized.Root), nil
+}
+
+// ConfirmValidatorsByIndex is GetSlotValidatorsByIndex with the quorum check.
+// It is used before recording exits or slashings, so a single lagging or faulty node can't produce them.
+func (c *Client) ConfirmValidatorsByIndex(slot uint64, validatorIndices []uint64) (map[string]*StandardValidatorEntry, error) {
+	if c.quorum <= 1 {
+		return c.GetSlotValidatorsByIndex(slot, validatorIndices)
+	}
+
+	res := make(map[string]*StandardValidatorEntry)
+	for i := 0; i < len(validatorIndices); i += defaultIndexChunkSize {
+		chunkEnd := i + defaultIndexChunkSize
+		if len(validatorIndices) < chunkEnd {
+			chunkEnd = len(validatorIndices)
+		}
+		chunk := validatorIndices[i:chunkEnd]
+
+		validatorsResp, err := utils.Retry(func() ([]byte, error) {
+			return c.getQuorum(fmt.Sprintf("/eth/v1/beacon/states/%d/validators?id=%s", slot, joinUint64(chunk, ",")), validatorStatusKey)
+		}, utils.DefaultRetryConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		parsedValidators := &StandardValidatorsResponse{}
+		err = json.Unmarshal(validatorsResp, parsedValidators)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing slot validators: %s", err)
+		}
+		for i := range parsedValidators.Data {
+			validator := parsedValidators.Data[i]
+			res[validator.Validator.Pubkey] = &validator
+		}
+	}
+	return res, nil
+}
+
+// validatorStatusKey only keeps the fields used for status, exit and slashing decisions
+func validatorStatusKey(data []byte) (string, error) {
+	parsedValidators := &StandardValidatorsResponse{}
+	err := json.Unmarshal(data, parsedValidators)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(parsedValidators.Data))
+	for _, v := range parsedValidators.Data {
+		keys = append(keys, fmt.Sprintf("%d:%s:%t:%d:%d", v.Index, v.Status, v.Validator.Slashed, v.Validator.ExitEpoch, v.Validator.WithdrawableEpoch))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ","), nil
+}
+
 type StandardV2BlockResponse struct {
 	Version             string         `json:"version"`
 	ExecutionOptimistic bool           `json:"execution_optimistic"`
@@ -505,7 +636,7 @@ type AnySignedBlock struct {
 // So don't use utils.Retry
 func (c *Client) GetBlockBySlot(slot uint64) (*StandardV2BlockResponse, error) {
 	return utils.Retry(func() (*StandardV2BlockResponse, error) {
-		resp, err := c.get(fmt.Sprintf("%s/eth/v2/beacon/blocks/%d", c.endpoint, slot))
+		resp, err := c.get(fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot))
 		if err != nil {
 			log.Warnf("error retrieving block data at slot %v: %s", slot, err)
 			return nil, err
@@ -520,14 +651,147 @@ func (c *Client) GetBlockBySlot(slot uint64) (*StandardV2BlockResponse, error) {
 	}, utils.DefaultRetryConfig)
 }
 
+type StandardAttestationRewardsResponse struct {
+	Data struct {
+		TotalRewards []AttestationReward `json:"total_rewards"`
+	} `json:"data"`
+}
+
+// AttestationReward values are in gwei and negative for penalties
+type AttestationReward struct {
+	ValidatorIndex uint64Str `json:"validator_index"`
+	Head           int64Str  `json:"head"`
+	Target         int64Str  `json:"target"`
+	Source         int64Str  `json:"source"`
+	Inactivity     int64Str  `json:"inactivity"`
+}
+
+// GetAttestationRewards returns the attestation rewards of the validators in epoch, the epoch has to be at least 2 epochs old
+func (c *Client) GetAttestationRewards(epoch uint64, validatorIndices []uint64) ([]AttestationReward, error) {
+	var res []AttestationReward
+	for i := 0; i < len(validatorIndices); i += defaultIndexChunkSize {
+		chunk := validatorIndices[i:min(i+defaultIndexChunkSize, len(validatorIndices))]
+		ids := make([]string, len(chunk))
+		for j, index := range chunk {
+			ids[j] = strconv.FormatUint(index, 10)
+		}
+		body, err := json.Marshal(ids)
+		if err != nil {
+			return nil, err
+		}
+
+		rewards, err := utils.Retry(func() ([]AttestationReward, error) {
+			resp, err := c.post(fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), body)
+			if err != nil {
+				log.Warnf("error retrieving attestation rewards for epoch %v: %s", epoch, err)
+				return nil, err
+			}
+
+			var parsedResponse StandardAttestationRewardsResponse
+			err = json.Unmarshal(resp, &parsedResponse)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing attestation rewards: %s", err)
+			}
+			return parsedResponse.Data.TotalRewards, nil
+		}, utils.DefaultRetryConfig)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rewards...)
+	}
+	return res, nil
+}
+
+// GetSlotBlock is like GetBlockBySlot, but a missed slot returns ErrNotFound at once instead of being retried
+func (c *Client) GetSlotBlock(slot uint64) (*StandardV2BlockResponse, error) {
+	missed := false
+	block, err := utils.Retry(func() (*StandardV2BlockResponse, error) {
+		resp, err := c.get(fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot))
+		if errors.Is(err, ErrNotFound) {
+			missed = true
+			return nil, nil
+		}
+		if err != nil {
+			log.Warnf("error retrieving block data at slot %v: %s", slot, err)
+			return nil, err
+		}
+		var parsedResponse StandardV2BlockResponse
+		err = json.Unmarshal(resp, &parsedResponse)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing block-response at slot %v: %s", slot, err)
+		}
+		return &parsedResponse, nil
+	}, utils.DefaultRetryConfig)
+	if missed {
+		return nil, ErrNotFound
+	}
+	return block, err
+}
+
+// GetValidatorCount returns the size of the validator registry at slot.
+// There is no standard endpoint for it, so the highest existing validator index is searched.
+func (c *Client) GetValidatorCount(slot uint64) (uint64, error) {
+	exist := func(index uint64) (bool, error) {
+		_, err := c.get(fmt.Sprintf("/eth/v1/beacon/states/%d/validators/%d", slot, index))
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	ok, err := exist(0)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	// low always exists, high never does
+	low, high := uint64(0), uint64(1)
+	for {
+		ok, err = exist(high)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		low, high = high, high*2
+	}
+
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		ok, err = exist(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return high, nil
+}
+
 var ErrNotFound = errors.New("not found 404")
 
-func (c *Client) get(url string) ([]byte, error) {
+func (c *Client) getFrom(url string) ([]byte, error) {
 	resp, err := c.httpClient.Get(url)
 	if err != nil {
 		return nil, err
 	}
+	return readResponse(url, resp)
+}
+
+func (c *Client) postTo(url string, body []byte) ([]byte, error) {
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	return readResponse(url, resp)
+}
 
+func readResponse(url string, resp *http.Response) ([]byte, error) {
 	defer resp.Body.Close()
 
 	data, err := io.ReadAll(resp.Body)
@@ -1,9 +1,14 @@
 package client
 
 import (
+	"encoding/json"
 	"errors"
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/monitorssv/monitorssv/config"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
 	"testing"
 )
 
@@ -104,3 +109,51 @@ func TestGetBlockBySlot(t *testing.T) {
 	}
 	t.Log(block)
 }
+
+func TestGetValidatorCount(t *testing.T) {
+	for _, count := range []uint64{0, 1, 2, 1000, 1025} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			index, err := strconv.ParseUint(path.Base(r.URL.Path), 10, 64)
+			if err != nil || index >= count {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}))
+
+		res, err := NewClient(server.URL).GetValidatorCount(100)
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != count {
+			t.Fatalf("expected %d, got %d", count, res)
+		}
+	}
+}
+
+func TestGetAttestationRewards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/eth/v1/beacon/rewards/attestations/100" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var ids []string
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil || len(ids) != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"ideal_rewards":[],"total_rewards":[
+			{"validator_index":"1","head":"2000","target":"4000","source":"3000","inactivity":"0"},
+			{"validator_index":"2","head":"0","target":"-4000","source":"-3000","inactivity":"-10"}]}}`))
+	}))
+	defer server.Close()
+
+	rewards, err := NewClient(server.URL).GetAttestationRewards(100, []uint64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rewards) != 2 || rewards[0].Source != 3000 || rewards[1].Source != -3000 || rewards[1].ValidatorIndex != 2 {
+		t.Fatalf("unexpected rewards: %v", rewards)
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"sync"
+	"time"
+)
+
+var errNoEndpoint = errors.New("no beacon endpoint configured")
+
+type endpoint = utils.Endpoint[struct{}]
+
+// Health reports the state of every endpoint, ordered by preference
+func (c *Client) Health() []utils.EndpointHealth {
+	return c.endpoints.Health()
+}
+
+func (c *Client) getFromEndpoint(ep *endpoint, path string) ([]byte, error) {
+	return c.callEndpoint(ep, func() ([]byte, error) {
+		return c.getFrom(ep.Url + path)
+	})
+}
+
+func (c *Client) postToEndpoint(ep *endpoint, path string, body []byte) ([]byte, error) {
+	return c.callEndpoint(ep, func() ([]byte, error) {
+		return c.postTo(ep.Url+path, body)
+	})
+}
+
+// callEndpoint records the outcome of a request in the endpoint health
+func (c *Client) callEndpoint(ep *endpoint, call func() ([]byte, error)) ([]byte, error) {
+	start := time.Now()
+	data, err := call()
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		ep.Failure(err)
+		return nil, err
+	}
+	ep.Success(time.Since(start))
+	return data, err
+}
+
+// get requests path from the healthiest endpoint and fails over to the next one on error.
+// ErrNotFound is a valid answer (e.g. missed slot) and is returned without failover.
+func (c *Client) get(path string) ([]byte, error) {
+	return c.failover(path, func(ep *endpoint) ([]byte, error) {
+		return c.getFromEndpoint(ep, path)
+	})
+}
+
+func (c *Client) post(path string, body []byte) ([]byte, error) {
+	return c.failover(path, func(ep *endpoint) ([]byte, error) {
+		return c.postToEndpoint(ep, path, body)
+	})
+}
+
+func (c *Client) failover(path string, call func(ep *endpoint) ([]byte, error)) ([]byte, error) {
+	return utils.Failover(c.endpoints, path, func(err error) bool {
+		return errors.Is(err, ErrNotFound)
+	}, call)
+}
+
+// getQuorum requests path from all endpoints and returns the response whose key is shared by at least quorum endpoints.
+// key extracts the fields that matter for the decision, so different encodings of the same answer agree.
+func (c *Client) getQuorum(path string, key func([]byte) (string, error)) ([]byte, error) {
+	if c.quorum <= 1 {
+		return c.get(path)
+	}
+
+	type answer struct {
+		data []byte
+		key  string
+		err  error
+	}
+
+	endpoints := c.endpoints.Sorted()
+	answers := make([]answer, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		wg.Add(1)
+		go func(i int, ep *endpoint) {
+			defer wg.Done()
+			data, err := c.getFromEndpoint(ep, path)
+			if err != nil {
+				answers[i] = answer{err: err}
+				return
+			}
+			k, err := key(data)
+			answers[i] = answer{data: data, key: k, err: err}
+		}(i, ep)
+	}
+	wg.Wait()
+
+	votes := make(map[string]int)
+	var lastErr error
+	for _, a := range answers {
+		if a.err != nil {
+			lastErr = a.err
+			continue
+		}
+		votes[a.key]++
+		if votes[a.key] >= c.quorum {
+			return a.data, nil
+		}
+	}
+
+	log.Warnw("getQuorum: quorum not reached", "path", path, "quorum", c.quorum, "votes", votes, "err", lastErr)
+	return nil, fmt.Errorf("beacon quorum of %d not reached for %s", c.quorum, path)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newFinalityStub(finalizedEpoch uint64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"data":{"finalized":{"epoch":"%d","root":"0x01"}}}`, finalizedEpoch)
+	}))
+}
+
+func TestFailover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+	up := newFinalityStub(101)
+	defer up.Close()
+
+	client := NewClient(down.URL, up.URL)
+	epoch, err := client.GetFinalizedEpoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epoch != 100 {
+		t.Fatalf("expected 100, got %d", epoch)
+	}
+
+	health := client.Health()
+	t.Log(health)
+	if health[0].Url != up.URL {
+		t.Fatal("healthy endpoint should be preferred")
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	good1 := newFinalityStub(101)
+	defer good1.Close()
+	good2 := newFinalityStub(101)
+	defer good2.Close()
+	lagging := newFinalityStub(90)
+	defer lagging.Close()
+
+	client := NewClient(lagging.URL, good1.URL, good2.URL)
+	client.SetQuorum(2)
+	epoch, err := client.GetFinalizedEpoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if epoch != 100 {
+		t.Fatalf("expected 100, got %d", epoch)
+	}
+
+	client = NewClient(lagging.URL, good1.URL)
+	client.SetQuorum(2)
+	if _, err = client.getQuorum("/eth/v1/beacon/states/head/finality_checkpoints", finalityKey); err == nil {
+		t.Fatal("expected quorum error")
+	}
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	TopicHead                = "head"
+	TopicBlock               = "block"
+	TopicFinalizedCheckpoint = "finalized_checkpoint"
+	TopicChainReorg          = "chain_reorg"
+)
+
+type HeadEvent struct {
+	Slot                uint64Str `json:"slot"`
+	Block               string    `json:"block"`
+	State               string    `json:"state"`
+	EpochTransition     bool      `json:"epoch_transition"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+type BlockEvent struct {
+	Slot                uint64Str `json:"slot"`
+	Block               string    `json:"block"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               string    `json:"block"`
+	State               string    `json:"state"`
+	Epoch               uint64Str `json:"epoch"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+type ChainReorgEvent struct {
+	Slot                uint64Str `json:"slot"`
+	Depth               uint64Str `json:"depth"`
+	OldHeadBlock        string    `json:"old_head_block"`
+	NewHeadBlock        string    `json:"new_head_block"`
+	OldHeadState        string    `json:"old_head_state"`
+	NewHeadState        string    `json:"new_head_state"`
+	Epoch               uint64Str `json:"epoch"`
+	ExecutionOptimistic bool      `json:"execution_optimistic"`
+}
+
+// Event is a decoded server-sent event, only the field matching Topic is set
+type Event struct {
+	Topic string
+
+	Head                *HeadEvent
+	Block               *BlockEvent
+	FinalizedCheckpoint *FinalizedCheckpointEvent
+	ChainReorg          *ChainReorgEvent
+}
+
+// SubscribeEvents streams /eth/v1/events into events until the stream ends or ctx is cancelled.
+// It always returns a non-nil error describing why the stream stopped.
+func (c *Client) SubscribeEvents(ctx context.Context, topics []string, events chan<- *Event) error {
+	ep := c.endpoints.Best()
+	if ep == nil {
+		return errNoEndpoint
+	}
+
+	url := fmt.Sprintf("%s/eth/v1/events?topics=%s", ep.Url, strings.Join(topics, ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	// the stream is long-lived, so the default client timeout can not be used
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		ep.Failure(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("url: %v, unexpected status: %d", url, resp.StatusCode)
+		ep.Failure(err)
+		return err
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var topic string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// blank line dispatches the event
+			if topic != "" && data.Len() != 0 {
+				event, err := decodeEvent(topic, data.Bytes())
+				if err != nil {
+					log.Warnw("SubscribeEvents: decodeEvent", "topic", topic, "err", err)
+				} else if event != nil {
+					select {
+					case <-ctx.Done():
+						return ctx.Err()
+					case events <- event:
+					}
+				}
+			}
+			topic = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// comment / keep-alive
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() != 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	err = scanner.Err()
+	if err == nil {
+		err = fmt.Errorf("event stream closed by %s", ep.Url)
+	}
+	// prefer another endpoint when reconnecting
+	ep.Failure(err)
+	return err
+}
+
+func decodeEvent(topic string, data []byte) (*Event, error) {
+	event := &Event{Topic: topic}
+	var err error
+	switch topic {
+	case TopicHead:
+		event.Head = new(HeadEvent)
+		err = json.Unmarshal(data, event.Head)
+	case TopicBlock:
+		event.Block = new(BlockEvent)
+		err = json.Unmarshal(data, event.Block)
+	case TopicFinalizedCheckpoint:
+		event.FinalizedCheckpoint = new(FinalizedCheckpointEvent)
+		err = json.Unmarshal(data, event.FinalizedCheckpoint)
+	case TopicChainReorg:
+		event.ChainReorg = new(ChainReorgEvent)
+		err = json.Unmarshal(data, event.ChainReorg)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
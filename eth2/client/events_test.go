@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newEventStub(t *testing.T, stream string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		t.Log("topics:", r.URL.Query().Get("topics"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, stream)
+	}))
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	stream := ": keep-alive\n\n" +
+		"event: head\ndata: {\"slot\":\"100\",\"block\":\"0xaa\",\"state\":\"0xbb\",\"epoch_transition\":false}\n\n" +
+		"event: block\ndata: {\"slot\":\"101\",\"block\":\"0xcc\",\"execution_optimistic\":false}\n\n" +
+		"event: finalized_checkpoint\ndata: {\"block\":\"0xdd\",\"state\":\"0xee\",\"epoch\":\"3\"}\n\n" +
+		"event: chain_reorg\ndata: {\"slot\":\"102\",\"depth\":\"2\",\"old_head_block\":\"0x01\",\"new_head_block\":\"0x02\",\"epoch\":\"3\"}\n\n" +
+		"event: unknown\ndata: {}\n\n"
+	server := newEventStub(t, stream)
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	events := make(chan *Event, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := client.SubscribeEvents(ctx, []string{TopicHead, TopicBlock, TopicFinalizedCheckpoint, TopicChainReorg}, events)
+	if err == nil {
+		t.Fatal("expected stream closed error")
+	}
+	close(events)
+
+	var received []*Event
+	for event := range events {
+		received = append(received, event)
+	}
+	if len(received) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(received))
+	}
+	if received[0].Head == nil || received[0].Head.Slot != 100 {
+		t.Fatal("unexpected head event", received[0])
+	}
+	if received[1].Block == nil || received[1].Block.Slot != 101 || received[1].Block.Block != "0xcc" {
+		t.Fatal("unexpected block event", received[1])
+	}
+	if received[2].FinalizedCheckpoint == nil || received[2].FinalizedCheckpoint.Epoch != 3 {
+		t.Fatal("unexpected finalized checkpoint event", received[2])
+	}
+	if received[3].ChainReorg == nil || received[3].ChainReorg.Depth != 2 {
+		t.Fatal("unexpected chain reorg event", received[3])
+	}
+}
+
+func TestSubscribeEventsCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-time.After(100 * time.Millisecond)
+		cancel()
+	}()
+
+	err := client.SubscribeEvents(ctx, []string{TopicHead}, make(chan *Event))
+	if err == nil {
+		t.Fatal("expected context error")
+	}
+	t.Log(err)
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// validatorSetTimeout bounds the download of the full validator set, it is hundreds of megabytes on mainnet
+const validatorSetTimeout = 5 * time.Minute
+
+// StreamValidators downloads the full validator set at slot and calls fn for every entry as it is decoded,
+// so the set is never held in memory. It fails over to the next endpoint if the download can not be started.
+func (c *Client) StreamValidators(slot uint64, fn func(entry *StandardValidatorEntry)) error {
+	path := fmt.Sprintf("/eth/v1/beacon/states/%d/validators", slot)
+	err := errNoEndpoint
+	for _, ep := range c.endpoints.Sorted() {
+		start := time.Now()
+		var started bool
+		started, err = c.streamValidatorsFrom(ep.Url+path, fn)
+		if err == nil {
+			ep.Success(time.Since(start))
+			return nil
+		}
+		ep.Failure(err)
+		if started {
+			// entries were handed out already, retrying would repeat them
+			return err
+		}
+		log.Warnw("beacon endpoint failed, try next", "endpoint", ep.Url, "path", path, "err", err)
+	}
+	return err
+}
+
+func (c *Client) streamValidatorsFrom(url string, fn func(entry *StandardValidatorEntry)) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validatorSetTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return false, fmt.Errorf("url: %v, error-response: %s", url, data)
+	}
+
+	started := false
+	err = decodeValidators(resp.Body, func(entry *StandardValidatorEntry) {
+		started = true
+		fn(entry)
+	})
+	return started, err
+}
+
+// decodeValidators walks a StandardValidatorsResponse token by token and decodes one entry of data at a time
+func decodeValidators(r io.Reader, fn func(entry *StandardValidatorEntry)) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := token.(string); key != "data" {
+			// execution_optimistic, finalized, ...
+			var skip json.RawMessage
+			if err = dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err = expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			var entry StandardValidatorEntry
+			if err = dec.Decode(&entry); err != nil {
+				return fmt.Errorf("error parsing validator entry: %s", err)
+			}
+			fn(&entry)
+		}
+		if err = expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("unexpected token %v, want %v", token, delim)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeValidators(t *testing.T) {
+	stream := `{"execution_optimistic": false, "finalized": true, "data": [
+		{"index": "1", "balance": "32000000000", "status": "active_ongoing", "validator": {"pubkey": "0x01", "effective_balance": "32000000000", "slashed": false}},
+		{"index": "2", "balance": "0", "status": "withdrawal_done", "validator": {"pubkey": "0x02", "effective_balance": "0", "slashed": true}}
+	]}`
+
+	var entries []*StandardValidatorEntry
+	err := decodeValidators(strings.NewReader(stream), func(entry *StandardValidatorEntry) {
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[1].Index != 2 || entries[1].Validator.Pubkey != "0x02" || !entries[1].Validator.Slashed || entries[1].Status != "withdrawal_done" {
+		t.Fatalf("unexpected entry %+v", entries[1])
+	}
+
+	err = decodeValidators(strings.NewReader(`{"data": [{"index": "1"}`), func(entry *StandardValidatorEntry) {})
+	if err == nil {
+		t.Fatal("expected error on truncated stream")
+	}
+}
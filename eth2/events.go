@@ -0,0 +1,203 @@
+package eth2
+
+import (
+	"context"
+	"github.com/monitorssv/monitorssv/alert"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+	"time"
+)
+
+var eventTopics = []string{
+	client.TopicHead,
+	client.TopicBlock,
+	client.TopicFinalizedCheckpoint,
+	client.TopicChainReorg,
+}
+
+const (
+	minStreamBackoff = 5 * time.Second
+	maxStreamBackoff = 2 * time.Minute
+)
+
+// EventStreamLoop subscribes to the beacon node event stream.
+// Blocks seen on the stream produce provisional proposal notifications, finalized checkpoints
+// trigger ScanBeaconBlockLoop right away. While the stream is down the loop keeps polling.
+func (bm *BeaconMonitor) EventStreamLoop() {
+	backoff := minStreamBackoff
+	for {
+		ctx, cancel := context.WithCancel(context.Background())
+		events := make(chan *client.Event, 64)
+		streamErr := make(chan error, 1)
+		go func() {
+			streamErr <- bm.client.SubscribeEvents(ctx, eventTopics, events)
+		}()
+
+		connected := time.Now()
+		err := bm.consumeEvents(events, streamErr)
+		cancel()
+		bm.isStreaming.Store(false)
+		// the slots until the next head on a new connection are not covered
+		bm.streamHeadSlot = 0
+		if err == nil {
+			// closed
+			return
+		}
+
+		if time.Since(connected) > maxStreamBackoff {
+			backoff = minStreamBackoff
+		}
+		log.Warnw("EventStreamLoop: event stream dropped, fallback to polling", "err", err, "retry", backoff.String())
+
+		select {
+		case <-bm.close:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxStreamBackoff {
+			backoff = maxStreamBackoff
+		}
+	}
+}
+
+// consumeEvents returns nil when the monitor is closed, otherwise the error which stopped the stream
+func (bm *BeaconMonitor) consumeEvents(events <-chan *client.Event, streamErr <-chan error) error {
+	for {
+		select {
+		case <-bm.close:
+			return nil
+		case err := <-streamErr:
+			return err
+		case event := <-events:
+			if !bm.isStreaming.Load() {
+				log.Info("EventStreamLoop: event stream connected")
+				bm.isStreaming.Store(true)
+			}
+
+			switch event.Topic {
+			case client.TopicHead:
+				bm.handleHeadEvent(event.Head)
+			case client.TopicBlock:
+				bm.handleBlockEvent(event.Block)
+			case client.TopicFinalizedCheckpoint:
+				log.Infow("EventStreamLoop: finalized checkpoint", "epoch", event.FinalizedCheckpoint.Epoch)
+				select {
+				case bm.finalizedCheckpointChan <- uint64(event.FinalizedCheckpoint.Epoch):
+				default:
+				}
+			case client.TopicChainReorg:
+				reorg := event.ChainReorg
+				log.Warnw("EventStreamLoop: chain reorg", "slot", reorg.Slot, "depth", reorg.Depth, "oldHead", reorg.OldHeadBlock, "newHead", reorg.NewHeadBlock)
+			}
+		}
+	}
+}
+
+func (bm *BeaconMonitor) handleBlockEvent(event *client.BlockEvent) {
+	slot := uint64(event.Slot)
+	bm.recordSeenBlock(slot, event.Block)
+	if !bm.isSynced.Load() {
+		return
+	}
+
+	proposer, validatorInfo := bm.getSSVProposer(slot)
+	if validatorInfo == nil {
+		return
+	}
+
+	log.Infow("handleBlockEvent: provisional ssv proposal", "slot", slot, "block", event.Block, "clusterId", validatorInfo.ClusterID)
+
+	// the stream reader must not wait for the alarm daemon, a dropped provisional alarm is sent once finalized
+	select {
+	case bm.validatorProposeBlockAlarmChan <- alert.ValidatorProposeBlockNotify{
+		Epoch:       bm.profile.SlotToEpoch(slot),
+		Slot:        slot,
+		ClusterId:   validatorInfo.ClusterID,
+		Index:       uint64(proposer.ValidatorIndex),
+		Provisional: true,
+	}:
+	default:
+		log.Warnw("handleBlockEvent: alarm channel full, drop provisional proposal", "slot", slot)
+	}
+}
+
+func (bm *BeaconMonitor) handleHeadEvent(event *client.HeadEvent) {
+	slot := uint64(event.Slot)
+	bm.recordSeenBlock(slot, event.Block)
+	bm.recordStreamCoverage(slot)
+	lastHeadSlot := bm.lastHeadSlot
+	if slot > bm.lastHeadSlot {
+		bm.lastHeadSlot = slot
+	}
+
+	if !bm.isSynced.Load() || lastHeadSlot == 0 || slot <= lastHeadSlot+1 {
+		return
+	}
+
+	// the head skipped some slots, those are most likely missed
+	if slot-lastHeadSlot > 2*bm.profile.SlotsPerEpoch {
+		log.Warnw("handleHeadEvent: head jumped too far, skip provisional missed blocks", "lastHeadSlot", lastHeadSlot, "slot", slot)
+		return
+	}
+
+	for missed := lastHeadSlot + 1; missed < slot; missed++ {
+		proposer, validatorInfo := bm.getSSVProposer(missed)
+		if validatorInfo == nil {
+			continue
+		}
+
+		// a block imported for the slot but skipped by the head was orphaned
+		reason := bm.missedReason(missed)
+
+		log.Infow("handleHeadEvent: provisional ssv missed block", "slot", missed, "clusterId", validatorInfo.ClusterID, "reason", reason)
+
+		select {
+		case bm.validatorMissedBlockAlarmChan <- alert.ValidatorMissedBlockNotify{
+			Epoch:       bm.profile.SlotToEpoch(missed),
+			Slot:        missed,
+			ClusterId:   validatorInfo.ClusterID,
+			Index:       uint64(proposer.ValidatorIndex),
+			Reason:      reason,
+			Provisional: true,
+		}:
+		default:
+			log.Warnw("handleHeadEvent: alarm channel full, drop provisional missed block", "slot", missed)
+		}
+	}
+}
+
+// getSSVProposer returns the proposer duty of the slot if the proposer is a ssv validator
+func (bm *BeaconMonitor) getSSVProposer(slot uint64) (*client.StandardProposerDuty, *store.ValidatorInfo) {
+	epoch := bm.profile.SlotToEpoch(slot)
+	if bm.dutiesEpoch != epoch || bm.duties == nil {
+		proposers, err := bm.client.GetEpochProposer(epoch)
+		if err != nil {
+			log.Warnw("getSSVProposer: GetEpochProposer", "epoch", epoch, "err", err)
+			return nil, nil
+		}
+
+		bm.duties = make(map[uint64]client.StandardProposerDuty)
+		for _, proposer := range proposers.Data {
+			bm.duties[uint64(proposer.Slot)] = proposer
+		}
+		bm.dutiesEpoch = epoch
+	}
+
+	proposer, ok := bm.duties[slot]
+	if !ok {
+		return nil, nil
+	}
+
+	validatorInfo, err := bm.store.GetValidatorByPublicKey(removePubKeyPrefix(proposer.Pubkey))
+	if err != nil {
+		log.Warnw("getSSVProposer: GetValidatorByPublicKey", "pubKey", proposer.Pubkey, "err", err)
+		return nil, nil
+	}
+	if validatorInfo == nil {
+		return nil, nil
+	}
+
+	return &proposer, validatorInfo
+}
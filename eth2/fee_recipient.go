@@ -0,0 +1,76 @@
+package eth2
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// verifyFeeRecipient compares the fee recipient of a proposed block with the cluster's fee recipient address in
+// effect at the block, which is the owner unless one was set. It returns the expected recipient, the block value
+// and whether it mismatched.
+func (bm *BeaconMonitor) verifyFeeRecipient(block BlockInfo, owner string) (string, *big.Int, bool, error) {
+	expected := owner
+	feeAddress, err := bm.store.GetClusterFeeAddressAt(owner, block.BlockNumber)
+	if err != nil {
+		return "", nil, false, err
+	}
+	if feeAddress != "" {
+		expected = feeAddress
+	}
+
+	ethBlock, err := bm.eth1Client.BlockByNumber(block.BlockNumber)
+	if err != nil {
+		return "", nil, false, err
+	}
+
+	if value, ok := mevPayment(ethBlock, common.HexToAddress(block.FeeRecipient), common.HexToAddress(expected)); ok {
+		return expected, value, false, nil
+	}
+
+	receipts, err := bm.eth1Client.BlockReceipts(block.BlockNumber)
+	if err != nil {
+		return "", nil, false, err
+	}
+	value := priorityFees(receipts, ethBlock.BaseFee())
+
+	mismatch := common.HexToAddress(block.FeeRecipient) != common.HexToAddress(expected)
+	if mismatch {
+		log.Warnw("verifyFeeRecipient: fee recipient mismatch", "slot", block.Slot, "feeRecipient", block.FeeRecipient, "expected", expected, "value", value)
+	}
+	return expected, value, mismatch, nil
+}
+
+// mevPayment returns the payment of a MEV-boost block: the builder is the fee recipient
+// and pays the proposer in the last transaction of the block
+func mevPayment(block *types.Block, feeRecipient, expected common.Address) (*big.Int, bool) {
+	if feeRecipient == expected {
+		return nil, false
+	}
+
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return nil, false
+	}
+	last := txs[len(txs)-1]
+	if last.To() == nil || *last.To() != expected {
+		return nil, false
+	}
+	return last.Value(), true
+}
+
+// priorityFees is what the fee recipient earns from the transactions, the base fee is burnt
+func priorityFees(receipts []*types.Receipt, baseFee *big.Int) *big.Int {
+	value := big.NewInt(0)
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+	for _, receipt := range receipts {
+		if receipt.EffectiveGasPrice == nil {
+			continue
+		}
+		tip := new(big.Int).Sub(receipt.EffectiveGasPrice, baseFee)
+		value.Add(value, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+	}
+	return value
+}
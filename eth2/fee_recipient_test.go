@@ -0,0 +1,39 @@
+package eth2
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestPriorityFees(t *testing.T) {
+	receipts := []*types.Receipt{
+		{EffectiveGasPrice: big.NewInt(12), GasUsed: 100},
+		{EffectiveGasPrice: big.NewInt(10), GasUsed: 50},
+		{GasUsed: 30},
+	}
+	value := priorityFees(receipts, big.NewInt(10))
+	if value.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("expected 200, got %v", value)
+	}
+}
+
+func TestMevPayment(t *testing.T) {
+	builder := common.HexToAddress("0x01")
+	expected := common.HexToAddress("0x02")
+	payment := types.NewTx(&types.LegacyTx{To: &expected, Value: big.NewInt(1000)})
+	block := types.NewBlockWithHeader(&types.Header{}).WithBody(types.Body{Transactions: []*types.Transaction{payment}})
+
+	value, ok := mevPayment(block, builder, expected)
+	if !ok || value.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("expected payment of 1000, got %v %v", value, ok)
+	}
+
+	if _, ok = mevPayment(block, expected, expected); ok {
+		t.Fatal("expected no payment when the fee recipient is expected")
+	}
+	if _, ok = mevPayment(block, builder, common.HexToAddress("0x03")); ok {
+		t.Fatal("expected no payment to another address")
+	}
+}
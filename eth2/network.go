@@ -0,0 +1,77 @@
+package eth2
+
+import (
+	"fmt"
+	"time"
+)
+
+// NetworkProfile holds the beacon chain parameters of a network
+type NetworkProfile struct {
+	Name           string
+	GenesisTime    uint64
+	SlotsPerEpoch  uint64
+	SecondsPerSlot uint64
+	// SSVDeploySlot is where proposal scanning starts
+	SSVDeploySlot uint64
+}
+
+// GetNetworkProfile
+// On holesky and hoodi the execution chain started at the beacon genesis, so a block number is never greater
+// than the slot it was proposed in, and the SSV deploy block is used as a safe lower bound of the deploy slot.
+func GetNetworkProfile(network string) (*NetworkProfile, error) {
+	switch network {
+	case "mainnet":
+		return &NetworkProfile{
+			Name:           network,
+			GenesisTime:    1606824023,
+			SlotsPerEpoch:  32,
+			SecondsPerSlot: 12,
+			// ssv deploy block: 17507487
+			SSVDeploySlot: 6689770,
+		}, nil
+	case "holesky":
+		return &NetworkProfile{
+			Name:           network,
+			GenesisTime:    1695902400,
+			SlotsPerEpoch:  32,
+			SecondsPerSlot: 12,
+			// ssv deploy block: 181612
+			SSVDeploySlot: 181612,
+		}, nil
+	case "hoodi":
+		return &NetworkProfile{
+			Name:           network,
+			GenesisTime:    1742213400,
+			SlotsPerEpoch:  32,
+			SecondsPerSlot: 12,
+			// ssv deploy block: 1065
+			SSVDeploySlot: 1065,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown network: %s", network)
+	}
+}
+
+func (p *NetworkProfile) SlotToEpoch(slot uint64) uint64 {
+	return slot / p.SlotsPerEpoch
+}
+
+func (p *NetworkProfile) EpochStartSlot(epoch uint64) uint64 {
+	return epoch * p.SlotsPerEpoch
+}
+
+func (p *NetworkProfile) EpochLastSlot(epoch uint64) uint64 {
+	return (epoch+1)*p.SlotsPerEpoch - 1
+}
+
+func (p *NetworkProfile) SlotTime(slot uint64) time.Time {
+	return time.Unix(int64(p.GenesisTime+slot*p.SecondsPerSlot), 0)
+}
+
+func (p *NetworkProfile) EpochTime(epoch uint64) time.Time {
+	return p.SlotTime(p.EpochStartSlot(epoch))
+}
+
+func (p *NetworkProfile) SlotDuration() time.Duration {
+	return time.Duration(p.SecondsPerSlot) * time.Second
+}
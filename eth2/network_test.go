@@ -0,0 +1,21 @@
+package eth2
+
+import "testing"
+
+func TestNetworkProfile(t *testing.T) {
+	for _, network := range []string{"mainnet", "holesky", "hoodi"} {
+		profile, err := GetNetworkProfile(network)
+		if err != nil {
+			t.Fatal(err)
+		}
+		epoch := profile.SlotToEpoch(profile.SSVDeploySlot)
+		t.Log(network, "deploy epoch", epoch, "time", profile.EpochTime(epoch).UTC())
+		if profile.EpochStartSlot(epoch) > profile.SSVDeploySlot || profile.EpochLastSlot(epoch) < profile.SSVDeploySlot {
+			t.Fatal("deploy slot outside its epoch")
+		}
+	}
+
+	if _, err := GetNetworkProfile("unknown"); err == nil {
+		t.Fatal("expected unknown network error")
+	}
+}
@@ -0,0 +1,238 @@
+package eth2
+
+import (
+	"errors"
+	"github.com/monitorssv/monitorssv/alert"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+	"time"
+)
+
+// minOfflineEpochs is the number of consecutive epochs without any performed duty after which an offline period is recorded
+const minOfflineEpochs = 2
+
+// validatorDuties are the duties of a validator in one epoch
+type validatorDuties struct {
+	Attested    bool
+	HasProposal bool
+	Proposed    bool
+}
+
+// performed is true if the validator did any of its duties, i.e. it was online
+func (d validatorDuties) performed() bool {
+	return d.Attested || d.Proposed
+}
+
+func (d validatorDuties) missed() uint64 {
+	var missed uint64
+	if !d.Attested {
+		missed++
+	}
+	if d.HasProposal && !d.Proposed {
+		missed++
+	}
+	return missed
+}
+
+// offlineStreak counts the processed epochs in which a validator missed all of its duties. Epochs whose duties
+// were not collected neither extend nor end a streak.
+type offlineStreak struct {
+	ClusterId    string
+	StartEpoch   uint64
+	Epochs       uint64
+	MissedDuties uint64
+	// Recorded is set once the streak is stored as a ValidatorOfflineInfo
+	Recorded bool
+}
+
+// proposalDuties checks whether the ssv validators proposed the blocks they were assigned in epoch
+func (bm *BeaconMonitor) proposalDuties(epoch uint64, clusters map[uint64]string) (map[uint64]validatorDuties, error) {
+	proposers, err := bm.client.GetEpochProposer(epoch)
+	if err != nil {
+		log.Warnw("proposalDuties: GetEpochProposer", "epoch", epoch, "err", err)
+		return nil, err
+	}
+
+	res := make(map[uint64]validatorDuties)
+	for _, proposer := range proposers.Data {
+		index := uint64(proposer.ValidatorIndex)
+		if _, ok := clusters[index]; !ok {
+			continue
+		}
+
+		_, err = bm.client.GetSlotBlock(uint64(proposer.Slot))
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			log.Warnw("proposalDuties: GetSlotBlock", "slot", proposer.Slot, "err", err)
+			return nil, err
+		}
+		res[index] = validatorDuties{HasProposal: true, Proposed: err == nil}
+	}
+	return res, nil
+}
+
+// loadOfflineStreaks restores the ongoing offline periods after a restart, so the recoveries are still reported.
+// The epochs the monitor was down are not counted.
+func (bm *BeaconMonitor) loadOfflineStreaks() error {
+	if bm.offlineStreaks != nil {
+		return nil
+	}
+
+	offlines, err := bm.store.GetOngoingValidatorOfflines()
+	if err != nil {
+		log.Errorw("GetOngoingValidatorOfflines", "err", err)
+		return err
+	}
+
+	bm.offlineStreaks = make(map[uint64]*offlineStreak)
+	for _, offline := range offlines {
+		bm.offlineStreaks[offline.ValidatorIndex] = &offlineStreak{
+			ClusterId:    offline.ClusterID,
+			StartEpoch:   offline.StartEpoch,
+			Epochs:       max(offline.Epochs, minOfflineEpochs),
+			MissedDuties: offline.MissedDuties,
+			Recorded:     true,
+		}
+	}
+	return nil
+}
+
+// detectOffline updates the offline streaks with the duties of epoch and notifies the clusters of offline and recovered validators
+func (bm *BeaconMonitor) detectOffline(epoch uint64, clusters map[uint64]string, duties map[uint64]validatorDuties) error {
+	err := bm.loadOfflineStreaks()
+	if err != nil {
+		return err
+	}
+
+	offlineAlarms := make(map[string]*alert.ValidatorOfflineNotify)
+	recoveredAlarms := make(map[string]*alert.ValidatorRecoveredNotify)
+	// online are the validators which performed their duties without closing a recorded offline period
+	var online []uint64
+	for index, duty := range duties {
+		clusterId := clusters[index]
+		streak := bm.offlineStreaks[index]
+
+		if duty.performed() {
+			if streak == nil || !streak.Recorded {
+				delete(bm.offlineStreaks, index)
+				online = append(online, index)
+				continue
+			}
+			delete(bm.offlineStreaks, index)
+
+			log.Infow("ValidatorRecovered", "index", index, "startEpoch", streak.StartEpoch, "epoch", epoch, "missedDuties", streak.MissedDuties)
+			err = bm.recordOnline(index, epoch, streak, true)
+			if err != nil {
+				return err
+			}
+
+			if recoveredAlarms[clusterId] == nil {
+				recoveredAlarms[clusterId] = &alert.ValidatorRecoveredNotify{Epoch: epoch, ClusterId: clusterId}
+			}
+			recoveredAlarms[clusterId].Validators = append(recoveredAlarms[clusterId].Validators, alert.ValidatorRecovered{
+				Index:        index,
+				StartEpoch:   streak.StartEpoch,
+				Epochs:       streak.Epochs,
+				MissedDuties: streak.MissedDuties,
+				Duration:     time.Duration(streak.Epochs*bm.profile.SlotsPerEpoch) * bm.profile.SlotDuration(),
+			})
+			continue
+		}
+
+		if streak == nil {
+			streak = &offlineStreak{ClusterId: clusterId, StartEpoch: epoch}
+			bm.offlineStreaks[index] = streak
+		}
+		prevEpochs := streak.Epochs
+		streak.Epochs++
+		streak.MissedDuties += duty.missed()
+
+		if streak.Epochs >= minOfflineEpochs {
+			if !streak.Recorded {
+				log.Infow("ValidatorOffline", "index", index, "clusterId", clusterId, "startEpoch", streak.StartEpoch)
+				err = bm.store.CreateValidatorOffline(&store.ValidatorOfflineInfo{
+					ClusterID:      clusterId,
+					ValidatorIndex: index,
+					StartEpoch:     streak.StartEpoch,
+					Epochs:         streak.Epochs,
+					MissedDuties:   streak.MissedDuties,
+				})
+				if err != nil {
+					log.Errorw("CreateValidatorOffline", "err", err)
+					return err
+				}
+				err = bm.store.UpdateValidatorOnlineStatus(int64(index), false)
+				if err != nil {
+					log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", index, "online", false)
+					return err
+				}
+				streak.Recorded = true
+			} else {
+				err = bm.store.UpdateValidatorOfflineDuties(index, streak.Epochs, streak.MissedDuties)
+				if err != nil {
+					log.Errorw("UpdateValidatorOfflineDuties", "err", err)
+					return err
+				}
+			}
+		}
+
+		if offlineAlarms[clusterId] == nil {
+			offlineAlarms[clusterId] = &alert.ValidatorOfflineNotify{Epoch: epoch, ClusterId: clusterId}
+		}
+		offlineAlarms[clusterId].Validators = append(offlineAlarms[clusterId].Validators, alert.ValidatorOffline{
+			Index:      index,
+			StartEpoch: streak.StartEpoch,
+			Epochs:     streak.Epochs,
+			PrevEpochs: prevEpochs,
+		})
+	}
+
+	// validators without duties exited or were removed, their offline period ends without a recovery
+	for index, streak := range bm.offlineStreaks {
+		if _, ok := duties[index]; ok {
+			continue
+		}
+		delete(bm.offlineStreaks, index)
+		if streak.Recorded {
+			err = bm.recordOnline(index, epoch, streak, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// validators can be offline without a recorded period, e.g. marked unknown before they were in the beacon state
+	err = bm.store.MarkValidatorsOnline(online)
+	if err != nil {
+		log.Errorw("MarkValidatorsOnline", "err", err)
+		return err
+	}
+
+	for _, offlineAlarm := range offlineAlarms {
+		bm.validatorOfflineAlarmChan <- *offlineAlarm
+	}
+	for _, recoveredAlarm := range recoveredAlarms {
+		bm.validatorRecoveredAlarmChan <- *recoveredAlarm
+	}
+
+	log.Infow("detectOffline", "epoch", epoch, "validators", len(duties), "offline", len(bm.offlineStreaks))
+	return nil
+}
+
+// recordOnline closes the offline period, the validator is only marked online again if it recovered
+func (bm *BeaconMonitor) recordOnline(index, epoch uint64, streak *offlineStreak, online bool) error {
+	err := bm.store.ValidatorRecovered(index, epoch, streak.Epochs, streak.MissedDuties)
+	if err != nil {
+		log.Errorw("ValidatorRecovered", "err", err)
+		return err
+	}
+
+	if !online {
+		return nil
+	}
+	err = bm.store.UpdateValidatorOnlineStatus(int64(index), true)
+	if err != nil {
+		log.Errorw("UpdateValidatorOnlineStatus", "err", err, "validatorIndex", index, "online", true)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package eth2
+
+import "testing"
+
+func TestValidatorDuties(t *testing.T) {
+	tests := []struct {
+		duties    validatorDuties
+		performed bool
+		missed    uint64
+	}{
+		{duties: validatorDuties{Attested: true}, performed: true, missed: 0},
+		{duties: validatorDuties{}, performed: false, missed: 1},
+		// a missed proposal alone does not make a validator offline
+		{duties: validatorDuties{Attested: true, HasProposal: true}, performed: true, missed: 1},
+		{duties: validatorDuties{HasProposal: true, Proposed: true}, performed: true, missed: 1},
+		{duties: validatorDuties{HasProposal: true}, performed: false, missed: 2},
+	}
+	for i, test := range tests {
+		if test.duties.performed() != test.performed {
+			t.Fatalf("case %d: expected performed %v", i, test.performed)
+		}
+		if test.duties.missed() != test.missed {
+			t.Fatalf("case %d: expected %d missed, got %d", i, test.missed, test.duties.missed())
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/store"
+)
+
+// recordSeenBlock remembers the blocks imported by the beacon node once synced, the slots scanned while catching
+// up are final already. A slot that ends up without a canonical block but was seen here was produced and orphaned.
+func (bm *BeaconMonitor) recordSeenBlock(slot uint64, root string) {
+	if !bm.isSynced.Load() {
+		return
+	}
+	bm.seenBlocksMu.Lock()
+	defer bm.seenBlocksMu.Unlock()
+	bm.seenBlocks[slot] = root
+}
+
+// recordStreamCoverage marks the slots since the previous head of the same connection as covered, the block events
+// of those slots arrived before this head. A head that jumps too far is not trusted to cover the skipped slots.
+func (bm *BeaconMonitor) recordStreamCoverage(slot uint64) {
+	prev := bm.streamHeadSlot
+	if slot > prev {
+		bm.streamHeadSlot = slot
+	}
+	if !bm.isSynced.Load() || prev == 0 || slot <= prev || slot-prev > 2*bm.profile.SlotsPerEpoch {
+		return
+	}
+	bm.seenBlocksMu.Lock()
+	defer bm.seenBlocksMu.Unlock()
+	for covered := prev + 1; covered <= slot; covered++ {
+		bm.coveredSlots[covered] = true
+	}
+}
+
+// forgetSeenBlocks drops the blocks and the coverage before slot, they are scanned already
+func (bm *BeaconMonitor) forgetSeenBlocks(slot uint64) {
+	bm.seenBlocksMu.Lock()
+	defer bm.seenBlocksMu.Unlock()
+	for seen := range bm.seenBlocks {
+		if seen < slot {
+			delete(bm.seenBlocks, seen)
+		}
+	}
+	for covered := range bm.coveredSlots {
+		if covered < slot {
+			delete(bm.coveredSlots, covered)
+		}
+	}
+}
+
+// missedReason tells a block that was reorged out from a slot without any block. It is called for slots the scan
+// found no canonical block for, so a block seen for the slot on the event stream was not the canonical one.
+// Without a live stream for the slot, e.g. after a restart or a stream drop, the reason is unknown.
+func (bm *BeaconMonitor) missedReason(slot uint64) string {
+	bm.seenBlocksMu.Lock()
+	defer bm.seenBlocksMu.Unlock()
+	if _, ok := bm.seenBlocks[slot]; ok {
+		return store.MissedOrphaned
+	}
+	if bm.coveredSlots[slot] {
+		return store.MissedNoBlock
+	}
+	return store.MissedUnknown
+}
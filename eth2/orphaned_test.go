@@ -0,0 +1,52 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/store"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMissedReason(t *testing.T) {
+	bm := &BeaconMonitor{
+		profile:      &NetworkProfile{SlotsPerEpoch: 32},
+		isSynced:     new(atomic.Bool),
+		seenBlocks:   make(map[uint64]string),
+		coveredSlots: make(map[uint64]bool),
+	}
+
+	// nothing is recorded while catching up
+	bm.recordSeenBlock(99, "0x01")
+	bm.recordStreamCoverage(99)
+	if bm.missedReason(99) != store.MissedUnknown {
+		t.Fatal("expected an unknown reason before sync")
+	}
+
+	bm.isSynced.Store(true)
+	bm.recordSeenBlock(100, "0x02")
+	bm.recordStreamCoverage(103)
+	if bm.missedReason(100) != store.MissedOrphaned {
+		t.Fatal("expected a seen block to be orphaned")
+	}
+	if bm.missedReason(101) != store.MissedNoBlock {
+		t.Fatal("expected no block for an unseen slot the stream covered")
+	}
+	if bm.missedReason(104) != store.MissedUnknown {
+		t.Fatal("expected an unknown reason for a slot the stream did not cover yet")
+	}
+
+	// a new connection only covers the slots after its first head
+	bm.streamHeadSlot = 0
+	bm.recordStreamCoverage(110)
+	if bm.missedReason(105) != store.MissedUnknown {
+		t.Fatal("expected an unknown reason for a slot during the stream drop")
+	}
+	bm.recordStreamCoverage(111)
+	if bm.missedReason(111) != store.MissedNoBlock {
+		t.Fatal("expected no block for a slot covered after the reconnect")
+	}
+
+	bm.forgetSeenBlocks(112)
+	if len(bm.seenBlocks) != 0 || len(bm.coveredSlots) != 0 {
+		t.Fatalf("expected scanned slots to be forgotten, got %v %v", bm.seenBlocks, bm.coveredSlots)
+	}
+}
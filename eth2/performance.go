@@ -0,0 +1,222 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"math"
+	"strings"
+	"time"
+)
+
+// performancePeriods are the rolling windows of the operator scores: 24h, 7d and 30d
+var performancePeriods = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+type dutyStats struct {
+	Attested           uint64
+	MissedAttestations uint64
+	Proposed           uint64
+	MissedProposals    uint64
+	Outages            uint64
+}
+
+func (s *dutyStats) add(o dutyStats) {
+	s.Attested += o.Attested
+	s.MissedAttestations += o.MissedAttestations
+	s.Proposed += o.Proposed
+	s.MissedProposals += o.MissedProposals
+	s.Outages += o.Outages
+}
+
+// score weights attestation effectiveness 80% and proposal success 20%, in percent with 2 decimals
+func (s *dutyStats) score() float64 {
+	attestations := s.Attested + s.MissedAttestations
+	if attestations == 0 {
+		return 0
+	}
+
+	score := float64(s.Attested) / float64(attestations)
+	if proposals := s.Proposed + s.MissedProposals; proposals > 0 {
+		score = 0.8*score + 0.2*float64(s.Proposed)/float64(proposals)
+	}
+	return math.Round(score*10000) / 100
+}
+
+// incidents are missed proposals and epochs in which most validators of a cluster missed their attestation
+func (s *dutyStats) incidents() uint64 {
+	return s.Outages + s.MissedProposals
+}
+
+// collectDuties counts the attestations of the active validators of every cluster from the attestation rewards.
+// An attestation is counted as missed when it did not earn the source reward, i.e. it was not included in time.
+// Together with the proposals the duties feed the offline detector.
+func (bm *BeaconMonitor) collectDuties(epoch uint64, clusterIndices map[string][]uint64) error {
+	var indices []uint64
+	clusters := make(map[uint64]string)
+	for clusterId, clusterIndex := range clusterIndices {
+		for _, index := range clusterIndex {
+			clusters[index] = clusterId
+			indices = append(indices, index)
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	rewards, err := bm.client.GetAttestationRewards(epoch, indices)
+	if err != nil {
+		log.Warnw("GetAttestationRewards", "epoch", epoch, "err", err)
+		return err
+	}
+
+	proposals, err := bm.proposalDuties(epoch, clusters)
+	if err != nil {
+		return err
+	}
+	indexDuties := attestationDuties(rewards, proposals, clusters)
+
+	duties := make(map[string]*dutyStats)
+	for index, duty := range indexDuties {
+		clusterId := clusters[index]
+		if duties[clusterId] == nil {
+			duties[clusterId] = &dutyStats{}
+		}
+		if duty.Attested {
+			duties[clusterId].Attested++
+		} else {
+			duties[clusterId].MissedAttestations++
+		}
+	}
+
+	for clusterId, duty := range duties {
+		var outages uint64
+		if duty.MissedAttestations*2 > duty.Attested+duty.MissedAttestations {
+			outages = 1
+		}
+		err = bm.store.AddClusterDuties(clusterId, epoch, duty.Attested, duty.MissedAttestations, outages)
+		if err != nil {
+			log.Errorw("AddClusterDuties", "err", err)
+			return err
+		}
+	}
+
+	log.Infow("collectDuties", "epoch", epoch, "validators", len(indices), "clusters", len(duties))
+
+	return bm.detectOffline(epoch, clusters, indexDuties)
+}
+
+// attestationDuties combines the attestation rewards with the proposals of the ssv validators. A validator without
+// a reward had no attestation duty processed in the epoch, it is left out instead of counted as missed.
+func attestationDuties(rewards []client.AttestationReward, proposals map[uint64]validatorDuties, clusters map[uint64]string) map[uint64]validatorDuties {
+	res := make(map[uint64]validatorDuties, len(rewards))
+	for _, reward := range rewards {
+		index := uint64(reward.ValidatorIndex)
+		if _, ok := clusters[index]; !ok {
+			continue
+		}
+		duty := proposals[index]
+		// a missed or wrong source vote is penalized, a correct one earns nothing during an inactivity leak
+		duty.Attested = reward.Source >= 0
+		res[index] = duty
+	}
+	return res
+}
+
+func (bm *BeaconMonitor) OperatorPerformanceLoop() {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-bm.close:
+			return
+		case <-ticker.C:
+			if !bm.isSynced.Load() {
+				continue
+			}
+			err := bm.updateOperatorPerformance()
+			if err != nil {
+				log.Errorw("updateOperatorPerformance", "err", err)
+			}
+		}
+	}
+}
+
+// updateOperatorPerformance aggregates the duties of all clusters an operator participates in
+func (bm *BeaconMonitor) updateOperatorPerformance() error {
+	curEpoch := bm.profile.SlotToEpoch(bm.lastProcessedSlot)
+	operators, err := bm.store.GetActiveOperators()
+	if err != nil {
+		log.Errorw("GetActiveOperators", "err", err)
+		return err
+	}
+
+	operatorStats := make([]map[uint64]*dutyStats, len(performancePeriods))
+	for i, period := range performancePeriods {
+		epochs := uint64(period / (bm.profile.SlotDuration() * time.Duration(bm.profile.SlotsPerEpoch)))
+		fromEpoch := uint64(0)
+		if curEpoch > epochs {
+			fromEpoch = curEpoch - epochs
+		}
+
+		clusterStats, err := bm.getClusterDutyStats(fromEpoch)
+		if err != nil {
+			return err
+		}
+
+		operatorStats[i] = make(map[uint64]*dutyStats)
+		for _, operator := range operators {
+			stats := &dutyStats{}
+			for _, clusterId := range strings.Split(operator.ClusterIds, ",") {
+				if cluster, ok := clusterStats[clusterId]; ok {
+					stats.add(*cluster)
+				}
+			}
+			operatorStats[i][operator.OperatorId] = stats
+		}
+	}
+
+	for _, operator := range operators {
+		day, week, month := operatorStats[0][operator.OperatorId], operatorStats[1][operator.OperatorId], operatorStats[2][operator.OperatorId]
+		err = bm.store.UpdateOperatorPerformance(operator.OperatorId, day.score(), week.score(), month.score(), month.incidents())
+		if err != nil {
+			log.Errorw("UpdateOperatorPerformance", "operatorId", operator.OperatorId, "err", err)
+			return err
+		}
+	}
+
+	log.Infow("updateOperatorPerformance", "epoch", curEpoch, "operators", len(operators))
+	return nil
+}
+
+func (bm *BeaconMonitor) getClusterDutyStats(fromEpoch uint64) (map[string]*dutyStats, error) {
+	res := make(map[string]*dutyStats)
+	get := func(clusterId string) *dutyStats {
+		if res[clusterId] == nil {
+			res[clusterId] = &dutyStats{}
+		}
+		return res[clusterId]
+	}
+
+	duties, err := bm.store.GetClusterDutyStats(fromEpoch)
+	if err != nil {
+		log.Errorw("GetClusterDutyStats", "err", err)
+		return nil, err
+	}
+	for _, duty := range duties {
+		stats := get(duty.ClusterID)
+		stats.Attested = duty.Attested
+		stats.MissedAttestations = duty.Missed
+		stats.Outages = duty.Outages
+	}
+
+	blocks, err := bm.store.GetClusterBlockStats(fromEpoch)
+	if err != nil {
+		log.Errorw("GetClusterBlockStats", "err", err)
+		return nil, err
+	}
+	for _, block := range blocks {
+		stats := get(block.ClusterID)
+		stats.Proposed = block.Proposed
+		stats.MissedProposals = block.Missed
+	}
+
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"testing"
+)
+
+func TestDutyStatsScore(t *testing.T) {
+	tests := []struct {
+		stats    dutyStats
+		expected float64
+	}{
+		{stats: dutyStats{}, expected: 0},
+		{stats: dutyStats{Attested: 99, MissedAttestations: 1}, expected: 99},
+		{stats: dutyStats{Attested: 99, MissedAttestations: 1, Proposed: 1, MissedProposals: 1}, expected: 89.2},
+		{stats: dutyStats{Attested: 2, MissedAttestations: 1}, expected: 66.67},
+	}
+	for _, test := range tests {
+		if score := test.stats.score(); score != test.expected {
+			t.Fatalf("%+v: expected %v, got %v", test.stats, test.expected, score)
+		}
+	}
+
+	stats := dutyStats{Outages: 2, MissedProposals: 1}
+	stats.add(dutyStats{Outages: 1})
+	if stats.incidents() != 4 {
+		t.Fatalf("expected 4 incidents, got %d", stats.incidents())
+	}
+}
+
+func TestAttestationDuties(t *testing.T) {
+	rewards := []client.AttestationReward{
+		{ValidatorIndex: 1, Source: 10},
+		{ValidatorIndex: 2, Source: -10},
+		// inactivity leak
+		{ValidatorIndex: 4, Source: 0},
+		// not a ssv validator
+		{ValidatorIndex: 9, Source: 10},
+	}
+	proposals := map[uint64]validatorDuties{
+		2: {HasProposal: true, Proposed: true},
+		// no attestation reward, nothing is known about its attestation
+		3: {HasProposal: true},
+	}
+	clusters := map[uint64]string{1: "a", 2: "a", 3: "b", 4: "b"}
+
+	duties := attestationDuties(rewards, proposals, clusters)
+	if len(duties) != 3 {
+		t.Fatalf("expected 3 validators, got %v", duties)
+	}
+	if duties[1] != (validatorDuties{Attested: true}) {
+		t.Fatalf("validator 1: %+v", duties[1])
+	}
+	if duties[2] != (validatorDuties{HasProposal: true, Proposed: true}) {
+		t.Fatalf("validator 2: %+v", duties[2])
+	}
+	if !duties[4].Attested {
+		t.Fatalf("validator 4: %+v", duties[4])
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/monitorssv/monitorssv/alert"
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"github.com/monitorssv/monitorssv/eth2/client"
@@ -18,11 +19,15 @@ type BlockInfo struct {
 	PubKey      string
 	Index       uint64
 	IsMissed    bool
+	// MissedReason is store.MissedNoBlock, store.MissedOrphaned or store.MissedUnknown for missed slots
+	MissedReason string
+	// FeeRecipient of the execution payload, empty for missed slots
+	FeeRecipient string
 }
 
 func (bm *BeaconMonitor) fetchBeaconBlocks(ctx context.Context, startEpoch, endEpoch uint64, parallel int) (<-chan BlockInfo, <-chan error) {
 	if endEpoch-startEpoch < uint64(parallel) {
-		parallel = int(endEpoch - startEpoch)
+		parallel = int(endEpoch-startEpoch) + 1
 	}
 
 	fetchBlocks := make(chan BlockInfo, 500)
@@ -96,6 +101,18 @@ func (bm *BeaconMonitor) processEpoch(ctx context.Context, fromEpoch uint64, fet
 		return err
 	}
 
+	// historical slashings are picked up by validatorMonitor, only fetch every block body once caught up
+	var blocks map[uint64]*client.StandardV2BlockResponse
+	if bm.isSynced.Load() {
+		blocks, err = bm.fetchEpochBlocks(fromEpoch)
+		if err != nil {
+			return err
+		}
+		if err = bm.checkSlashings(fromEpoch, blocks); err != nil {
+			return err
+		}
+	}
+
 	for _, proposer := range proposers.Data {
 		pubKey := removePubKeyPrefix(proposer.Pubkey)
 		validatorInfo, err := bm.store.GetValidatorByPublicKey(pubKey)
@@ -107,22 +124,35 @@ func (bm *BeaconMonitor) processEpoch(ctx context.Context, fromEpoch uint64, fet
 			continue
 		}
 
-		blockNumber, err := bm.getEth1ExBlock(uint64(proposer.Slot))
-		if err != nil {
-			return err
-		}
-
 		isMissed := false
-		_, err = bm.client.GetSlotHeader(uint64(proposer.Slot))
+		var feeRecipient string
+		block, err := bm.slotBlock(blocks, uint64(proposer.Slot))
 		if errors.Is(err, client.ErrNotFound) {
 			// slot missed
 			isMissed = true
 		} else if err != nil {
-			log.Warnw("GetSlotHeader", "proposer", proposer.Slot, "err", err)
+			log.Warnw("GetSlotBlock", "proposer", proposer.Slot, "err", err)
 			return err
 		}
 
-		log.Infow("GetSlotHeader", "proposer", proposer.Pubkey, "slot", proposer.Slot, "blockNumber", blockNumber, "isMissed", isMissed)
+		var missedReason string
+		if isMissed {
+			missedReason = bm.missedReason(uint64(proposer.Slot))
+		}
+
+		var blockNumber uint64
+		if !isMissed && block.Data.Message.Body.ExecutionPayload != nil {
+			payload := block.Data.Message.Body.ExecutionPayload
+			blockNumber = uint64(payload.BlockNumber)
+			feeRecipient = common.BytesToAddress(payload.FeeRecipient).String()
+		} else {
+			blockNumber, err = bm.getEth1ExBlock(uint64(proposer.Slot))
+			if err != nil {
+				return err
+			}
+		}
+
+		log.Infow("GetSlotBlock", "proposer", proposer.Pubkey, "slot", proposer.Slot, "blockNumber", blockNumber, "isMissed", isMissed, "missedReason", missedReason)
 
 		select {
 		case <-ctx.Done():
@@ -131,12 +161,14 @@ func (bm *BeaconMonitor) processEpoch(ctx context.Context, fromEpoch uint64, fet
 			return fmt.Errorf("close")
 		default:
 			fetchBlocks <- BlockInfo{
-				BlockNumber: blockNumber,
-				Epoch:       fromEpoch,
-				Slot:        uint64(proposer.Slot),
-				PubKey:      proposer.Pubkey,
-				Index:       uint64(proposer.ValidatorIndex),
-				IsMissed:    isMissed,
+				BlockNumber:  blockNumber,
+				Epoch:        fromEpoch,
+				Slot:         uint64(proposer.Slot),
+				PubKey:       proposer.Pubkey,
+				Index:        uint64(proposer.ValidatorIndex),
+				IsMissed:     isMissed,
+				MissedReason: missedReason,
+				FeeRecipient: feeRecipient,
 			}
 		}
 	}
@@ -158,18 +190,28 @@ func (bm *BeaconMonitor) getEth1ExBlock(slot uint64) (uint64, error) {
 
 }
 
-func slotToEpoch(slot uint64) uint64 {
-	slotsPerEpoch := uint64(32)
-	return slot / slotsPerEpoch
-}
-
-func (bm *BeaconMonitor) handleBlocks(fetchBlocks <-chan BlockInfo) (uint64, error) {
+// handleBlocks records the proposals, it returns the last processed slot and the slots that could not be recorded
+func (bm *BeaconMonitor) handleBlocks(fetchBlocks <-chan BlockInfo) (uint64, []uint64, error) {
 	var lastProcessedSlot uint64
+	var failed []uint64
 	for block := range fetchBlocks {
+		// a rescanned or backfilled slot was handled and alerted already
+		recorded, err := bm.store.IsBlockRecorded(block.Slot)
+		if err != nil {
+			log.Warnw("IsBlockRecorded", "slot", block.Slot, "err", err)
+			failed = append(failed, block.Slot)
+			continue
+		}
+		if recorded {
+			lastProcessedSlot = max(lastProcessedSlot, block.Slot)
+			continue
+		}
+
 		pubKey := removePubKeyPrefix(block.PubKey)
 		validatorInfo, err := bm.store.GetValidatorByPubKeyAndBlock(pubKey, block.BlockNumber)
 		if err != nil {
 			log.Warnw("GetValidatorByPubKeyAndBlock", "err", err)
+			failed = append(failed, block.Slot)
 			continue
 		}
 
@@ -186,8 +228,34 @@ func (bm *BeaconMonitor) handleBlocks(fetchBlocks <-chan BlockInfo) (uint64, err
 		}
 
 		blockNumber := uint64(0)
+		blockValue := "0"
+		feeRecipientMismatch := false
 		if !block.IsMissed {
 			blockNumber = block.BlockNumber
+			// the execution blocks are only fetched once caught up, older proposals keep a zero value
+			if block.FeeRecipient != "" && bm.isSynced.Load() {
+				expected, value, mismatch, err := bm.verifyFeeRecipient(block, validatorInfo.Owner)
+				if err != nil {
+					log.Warnw("verifyFeeRecipient", "slot", block.Slot, "err", err)
+				} else {
+					blockValue = value.String()
+					feeRecipientMismatch = mismatch
+				}
+
+				if feeRecipientMismatch {
+					bm.feeRecipientMismatchAlarmChan <- alert.FeeRecipientMismatchNotify{
+						Epoch:             block.Epoch,
+						Slot:              block.Slot,
+						BlockNumber:       block.BlockNumber,
+						ClusterId:         validatorInfo.ClusterID,
+						Index:             block.Index,
+						FeeRecipient:      block.FeeRecipient,
+						ExpectedRecipient: expected,
+						BlockValue:        value,
+					}
+				}
+			}
+
 			if bm.isSynced.Load() {
 				// propose block alarm
 				bm.validatorProposeBlockAlarmChan <- alert.ValidatorProposeBlockNotify{
@@ -205,23 +273,29 @@ func (bm *BeaconMonitor) handleBlocks(fetchBlocks <-chan BlockInfo) (uint64, err
 					Slot:      block.Slot,
 					ClusterId: validatorInfo.ClusterID,
 					Index:     block.Index,
+					Reason:    block.MissedReason,
 				}
 			}
 		}
 
-		log.Infow("CreateBlock", "slot", block.Slot, "pubKey", pubKey, "clusterId", validatorInfo.ClusterID, "isMiss", block.IsMissed)
+		log.Infow("CreateBlock", "slot", block.Slot, "pubKey", pubKey, "clusterId", validatorInfo.ClusterID, "isMiss", block.IsMissed, "missedReason", block.MissedReason)
 
 		err = bm.store.CreateBlock(&store.BlockInfo{
-			ClusterID:   validatorInfo.ClusterID,
-			BlockNumber: blockNumber,
-			Epoch:       block.Epoch,
-			Slot:        block.Slot,
-			Proposer:    block.Index,
-			PublicKey:   pubKey,
-			IsMissed:    block.IsMissed,
+			ClusterID:            validatorInfo.ClusterID,
+			BlockNumber:          blockNumber,
+			Epoch:                block.Epoch,
+			Slot:                 block.Slot,
+			Proposer:             block.Index,
+			PublicKey:            pubKey,
+			IsMissed:             block.IsMissed,
+			MissedReason:         block.MissedReason,
+			FeeRecipient:         block.FeeRecipient,
+			BlockValue:           blockValue,
+			FeeRecipientMismatch: feeRecipientMismatch,
 		})
 		if err != nil {
 			log.Warnw("CreateBlock", "err", err)
+			failed = append(failed, block.Slot)
 			continue
 		}
 
@@ -230,7 +304,7 @@ func (bm *BeaconMonitor) handleBlocks(fetchBlocks <-chan BlockInfo) (uint64, err
 		}
 	}
 
-	return lastProcessedSlot, nil
+	return lastProcessedSlot, failed, nil
 }
 
 func removePubKeyPrefix(pubKey string) string {
@@ -0,0 +1,97 @@
+package eth2
+
+import (
+	"fmt"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+)
+
+// validatorSet is the validator set at a slot, restricted to the ssv validators
+type validatorSet struct {
+	// entries by 0x-prefixed public key
+	entries map[string]*client.StandardValidatorEntry
+	// missing are the 0x-prefixed public keys of the ssv validators that are not in the beacon state
+	missing map[string]bool
+	// totalActiveBalance is the effective balance in gwei of all active validators, it sets the churn limit
+	totalActiveBalance uint64
+}
+
+// resolveValidators streams the validator set at slot once and keeps the entries of the ssv validators by 0x-prefixed public key.
+// Missing indices are stored and validators that are not in the beacon state are marked unknown, both in batches.
+func (bm *BeaconMonitor) resolveValidators(slot uint64) (*validatorSet, error) {
+	keys, err := bm.store.GetValidatorKeys()
+	if err != nil {
+		log.Errorw("GetValidatorKeys", "err", err)
+		return nil, err
+	}
+
+	ssvPubKeys := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		ssvPubKeys[fmt.Sprintf("0x%s", key.PublicKey)] = true
+	}
+
+	entries := make(map[string]*client.StandardValidatorEntry, len(keys))
+	var total int
+	var totalActiveBalance uint64
+	err = bm.client.StreamValidators(slot, func(entry *client.StandardValidatorEntry) {
+		total++
+		if store.GetStatusDescription(entry.Status) == store.ValidatorActive || entry.Status == store.ActiveSlashed {
+			totalActiveBalance += uint64(entry.Validator.EffectiveBalance)
+		}
+		if ssvPubKeys[entry.Validator.Pubkey] {
+			entries[entry.Validator.Pubkey] = entry
+		}
+	})
+	if err != nil {
+		log.Warnw("StreamValidators", "slot", slot, "err", err)
+		return nil, err
+	}
+
+	indices, unknown := resolveIndices(keys, entries)
+	if len(indices) > 0 {
+		log.Infow("BatchUpdateValidatorIndex", "validators", len(indices))
+		err = bm.store.BatchUpdateValidatorIndex(indices)
+		if err != nil {
+			log.Errorw("BatchUpdateValidatorIndex", "err", err)
+			return nil, err
+		}
+	}
+	if len(unknown) > 0 {
+		log.Infow("MarkValidatorsUnknown", "validators", len(unknown))
+		err = bm.store.MarkValidatorsUnknown(unknown)
+		if err != nil {
+			log.Errorw("MarkValidatorsUnknown", "err", err)
+			return nil, err
+		}
+	}
+
+	missing := make(map[string]bool)
+	for pubKey := range ssvPubKeys {
+		if _, ok := entries[pubKey]; !ok {
+			missing[pubKey] = true
+		}
+	}
+
+	log.Infow("resolveValidators", "slot", slot, "beaconValidators", total, "ssvValidators", len(keys), "found", len(entries), "totalActiveBalance", totalActiveBalance)
+	return &validatorSet{entries: entries, missing: missing, totalActiveBalance: totalActiveBalance}, nil
+}
+
+// resolveIndices returns the indices of the validators without one, and the validators which are not in the beacon state yet
+func resolveIndices(keys []store.ValidatorKey, entries map[string]*client.StandardValidatorEntry) (map[string]int64, []string) {
+	indices := make(map[string]int64)
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if key.ValidatorIndex != store.DefaultValidatorIndex || seen[key.PublicKey] {
+			continue
+		}
+		seen[key.PublicKey] = true
+
+		if entry, ok := entries[fmt.Sprintf("0x%s", key.PublicKey)]; ok {
+			indices[key.PublicKey] = int64(entry.Index)
+		} else if key.Status != store.ValidatorUnknown {
+			unknown = append(unknown, key.PublicKey)
+		}
+	}
+	return indices, unknown
+}
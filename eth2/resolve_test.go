@@ -0,0 +1,30 @@
+package eth2
+
+import (
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+	"testing"
+)
+
+func TestResolveIndices(t *testing.T) {
+	keys := []store.ValidatorKey{
+		{PublicKey: "01", ValidatorIndex: store.DefaultValidatorIndex},
+		// registered in two clusters
+		{PublicKey: "01", ValidatorIndex: store.DefaultValidatorIndex},
+		{PublicKey: "02", ValidatorIndex: 7, Status: store.ValidatorActive},
+		{PublicKey: "03", ValidatorIndex: store.DefaultValidatorIndex, Status: store.ValidatorPending},
+		{PublicKey: "04", ValidatorIndex: store.DefaultValidatorIndex, Status: store.ValidatorUnknown},
+	}
+	entries := map[string]*client.StandardValidatorEntry{
+		"0x01": {Index: 5},
+		"0x02": {Index: 7},
+	}
+
+	indices, unknown := resolveIndices(keys, entries)
+	if len(indices) != 1 || indices["01"] != 5 {
+		t.Fatalf("unexpected indices %v", indices)
+	}
+	if len(unknown) != 1 || unknown[0] != "03" {
+		t.Fatalf("unexpected unknown validators %v", unknown)
+	}
+}
@@ -8,8 +8,11 @@ import (
 import (
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/monitorssv/monitorssv/config"
+	eth1client "github.com/monitorssv/monitorssv/eth1/client"
+	"github.com/monitorssv/monitorssv/eth1/utils"
 	"github.com/monitorssv/monitorssv/eth2/client"
 	"github.com/monitorssv/monitorssv/store"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -17,10 +20,12 @@ import (
 var log = logging.Logger("beacon")
 
 type BeaconMonitor struct {
-	cfg *config.Config
+	cfg     *config.Config
+	profile *NetworkProfile
 
-	client *client.Client
-	store  *store.Store
+	client     *client.Client
+	eth1Client *eth1client.Eth1Client
+	store      *store.Store
 
 	lastProcessedSlot uint64
 	isSynced          *atomic.Bool
@@ -28,25 +33,63 @@ type BeaconMonitor struct {
 	lastValidatorMonitorEpoch uint64
 	validatorBalanceHistory   map[uint64][3]Balance
 
-	validatorProposeBlockAlarmChan chan<- alert.ValidatorProposeBlockNotify
-	validatorMissedBlockAlarmChan  chan<- alert.ValidatorMissedBlockNotify
-	validatorBalanceDeltaAlarmChan chan<- alert.ValidatorBalanceDeltaNotify
-	validatorSlashAlarmChan        chan<- alert.ValidatorSlashNotify
+	isStreaming             *atomic.Bool
+	lastHeadSlot            uint64
+	dutiesEpoch             uint64
+	duties                  map[uint64]client.StandardProposerDuty
+	finalizedCheckpointChan chan uint64
+
+	// seenBlocks are the block roots seen on the event stream by slot, until the slot is scanned.
+	// coveredSlots are the slots the stream was live for, any block of those would be in seenBlocks.
+	seenBlocksMu sync.Mutex
+	seenBlocks   map[uint64]string
+	coveredSlots map[uint64]bool
+	// streamHeadSlot is the last head of the current stream connection, 0 until the first head
+	streamHeadSlot uint64
+
+	// offlineStreaks by validator index, nil until the ongoing offline periods are loaded
+	offlineStreaks map[uint64]*offlineStreak
+
+	// sweepSearchedEpochs is the oldest epoch searched for the withdrawal of a swept validator, by validator index
+	sweepSearchedEpochs map[uint64]uint64
+
+	sweepMu             sync.RWMutex
+	sweep               withdrawalSweep
+	prevSweep           withdrawalSweep
+	validatorCount      uint64
+	validatorCountEpoch uint64
+
+	validatorProposeBlockAlarmChan     chan<- alert.ValidatorProposeBlockNotify
+	validatorMissedBlockAlarmChan      chan<- alert.ValidatorMissedBlockNotify
+	validatorBalanceDeltaAlarmChan     chan<- alert.ValidatorBalanceDeltaNotify
+	validatorSlashAlarmChan            chan<- alert.ValidatorSlashNotify
+	validatorSlashingEvidenceAlarmChan chan<- alert.ValidatorSlashingEvidenceNotify
+	feeRecipientMismatchAlarmChan      chan<- alert.FeeRecipientMismatchNotify
+	validatorActivatedAlarmChan        chan<- alert.ValidatorActivatedNotify
+	validatorWithdrawnAlarmChan        chan<- alert.ValidatorWithdrawnNotify
+	validatorOfflineAlarmChan          chan<- alert.ValidatorOfflineNotify
+	validatorRecoveredAlarmChan        chan<- alert.ValidatorRecoveredNotify
 
 	close chan struct{}
 }
 
-func NewBeaconMonitor(cfg *config.Config, client *client.Client, store *store.Store, alarm *alert.AlarmDaemon) (*BeaconMonitor, error) {
-	// ssv deploy block: 17507487
-	lastProcessedSlot := uint64(6689770)
+func NewBeaconMonitor(cfg *config.Config, client *client.Client, eth1Client *eth1client.Eth1Client, store *store.Store, alarm *alert.AlarmDaemon) (*BeaconMonitor, error) {
+	profile, err := GetNetworkProfile(cfg.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	lastProcessedSlot := profile.SSVDeploySlot
 	if _, slot, err := store.GetScanPoint(); err == nil && slot != 0 {
 		lastProcessedSlot = slot
 	}
 
 	bm := BeaconMonitor{
-		cfg:    cfg,
-		client: client,
-		store:  store,
+		cfg:        cfg,
+		profile:    profile,
+		client:     client,
+		eth1Client: eth1Client,
+		store:      store,
 
 		lastProcessedSlot: lastProcessedSlot,
 		isSynced:          new(atomic.Bool),
@@ -54,20 +97,36 @@ func NewBeaconMonitor(cfg *config.Config, client *client.Client, store *store.St
 		lastValidatorMonitorEpoch: 0,
 		validatorBalanceHistory:   make(map[uint64][3]Balance),
 
-		validatorProposeBlockAlarmChan: alarm.ValidatorProposeBlockChan(),
-		validatorMissedBlockAlarmChan:  alarm.ValidatorMissedBlockChan(),
-		validatorBalanceDeltaAlarmChan: alarm.ValidatorBalanceDeltaChan(),
-		validatorSlashAlarmChan:        alarm.ValidatorSlashNotifyChan(),
+		isStreaming:             new(atomic.Bool),
+		finalizedCheckpointChan: make(chan uint64, 1),
+		seenBlocks:              make(map[uint64]string),
+		coveredSlots:            make(map[uint64]bool),
+		sweepSearchedEpochs:     make(map[uint64]uint64),
 
 		close: make(chan struct{}),
 	}
 
+	// alarm is nil for offline jobs like backfill, which never notify
+	if alarm != nil {
+		bm.validatorProposeBlockAlarmChan = alarm.ValidatorProposeBlockChan()
+		bm.validatorMissedBlockAlarmChan = alarm.ValidatorMissedBlockChan()
+		bm.validatorBalanceDeltaAlarmChan = alarm.ValidatorBalanceDeltaChan()
+		bm.validatorSlashAlarmChan = alarm.ValidatorSlashNotifyChan()
+		bm.validatorSlashingEvidenceAlarmChan = alarm.ValidatorSlashingEvidenceChan()
+		bm.feeRecipientMismatchAlarmChan = alarm.FeeRecipientMismatchChan()
+		bm.validatorActivatedAlarmChan = alarm.ValidatorActivatedChan()
+		bm.validatorWithdrawnAlarmChan = alarm.ValidatorWithdrawnChan()
+		bm.validatorOfflineAlarmChan = alarm.ValidatorOfflineChan()
+		bm.validatorRecoveredAlarmChan = alarm.ValidatorRecoveredChan()
+	}
+
 	bm.isSynced.Store(false)
 	return &bm, nil
 }
 
 func (bm *BeaconMonitor) Start() {
-	if bm.cfg.Network != "mainnet" {
+	if !bm.Enabled() {
+		log.Info("Beacon monitor has no beacon endpoint configured")
 		return
 	}
 
@@ -80,12 +139,23 @@ func (bm *BeaconMonitor) Start() {
 
 	go bm.ScanBeaconBlockLoop()
 	go bm.ValidatorMonitorLoop()
+	go bm.EventStreamLoop()
+	go bm.OperatorPerformanceLoop()
 }
 
 func (bm *BeaconMonitor) Stop() {
 	close(bm.close)
 }
 
+// Enabled reports whether a beacon endpoint is configured
+func (bm *BeaconMonitor) Enabled() bool {
+	return len(bm.cfg.Eth2Endpoints()) != 0
+}
+
+func (bm *BeaconMonitor) GetProfile() *NetworkProfile {
+	return bm.profile
+}
+
 func (bm *BeaconMonitor) GetLastProcessedSlot() uint64 {
 	return bm.lastProcessedSlot
 }
@@ -94,49 +164,68 @@ func (bm *BeaconMonitor) GetLastValidatorMonitorEpoch() uint64 {
 	return bm.lastValidatorMonitorEpoch
 }
 
+// GetEndpointHealth reports the state of the beacon endpoints
+func (bm *BeaconMonitor) GetEndpointHealth() []utils.EndpointHealth {
+	return bm.client.Health()
+}
+
 func (bm *BeaconMonitor) ScanBeaconBlockLoop() {
 	ticker := time.NewTicker(10 * time.Second)
 	for {
 		select {
 		case <-bm.close:
 			return
+		case epoch := <-bm.finalizedCheckpointChan:
+			log.Infow("ScanBeaconBlockLoop: triggered by finalized checkpoint", "epoch", epoch)
+			if bm.scanFinalized() {
+				ticker.Reset(13 * time.Minute)
+			}
 		case <-ticker.C:
-			finalizedEpoch, err := bm.client.GetFinalizedEpoch()
-			if err != nil {
-				log.Warnw("failed to fetch finalized epoch", "err", err)
-				continue
+			if bm.scanFinalized() {
+				ticker.Reset(13 * time.Minute)
 			}
+		}
+	}
+}
 
-			finalizedSlot := finalizedEpoch * 32
+// scanFinalized scans proposals up to the finalized epoch, it returns true once the scan has caught up
+func (bm *BeaconMonitor) scanFinalized() bool {
+	finalizedEpoch, err := bm.client.GetFinalizedEpoch()
+	if err != nil {
+		log.Warnw("failed to fetch finalized epoch", "err", err)
+		return bm.isSynced.Load()
+	}
 
-			log.Infow("ScanBeaconBlockLoop", "lastProcessedSlot", bm.lastProcessedSlot, "finalizedEpoch", finalizedEpoch)
+	finalizedSlot := bm.profile.EpochStartSlot(finalizedEpoch)
 
-			lastProcessedSlot, err := bm.ScanProposer(bm.lastProcessedSlot, finalizedEpoch)
-			if err != nil {
-				log.Warnw("failed to scan beacon block", "err", err)
-			}
+	log.Infow("ScanBeaconBlockLoop", "lastProcessedSlot", bm.lastProcessedSlot, "finalizedEpoch", finalizedEpoch, "streaming", bm.isStreaming.Load())
 
-			if lastProcessedSlot > bm.lastProcessedSlot {
-				bm.lastProcessedSlot = lastProcessedSlot
-				err = bm.store.UpdateScanEth2Slot(lastProcessedSlot)
-				if err != nil {
-					log.Errorw("failed to update beacon block", "err", err)
-				}
-			}
+	lastProcessedSlot, err := bm.ScanProposer(bm.lastProcessedSlot, finalizedEpoch)
+	if err != nil {
+		log.Warnw("failed to scan beacon block", "err", err)
+	}
 
-			if !bm.isSynced.Load() {
-				if lastProcessedSlot >= finalizedSlot {
-					log.Infow("ScanBeaconBlockLoop: Sync completed", "lastProcessedSlot", lastProcessedSlot, "finalizedSlot", finalizedSlot)
-					bm.isSynced.Store(true)
-					ticker = time.NewTicker(13 * time.Minute)
-				}
-			}
+	if lastProcessedSlot > bm.lastProcessedSlot {
+		bm.lastProcessedSlot = lastProcessedSlot
+		bm.forgetSeenBlocks(lastProcessedSlot)
+		err = bm.store.UpdateScanEth2Slot(lastProcessedSlot)
+		if err != nil {
+			log.Errorw("failed to update beacon block", "err", err)
 		}
 	}
+
+	if !bm.isSynced.Load() {
+		if lastProcessedSlot >= finalizedSlot {
+			log.Infow("ScanBeaconBlockLoop: Sync completed", "lastProcessedSlot", lastProcessedSlot, "finalizedSlot", finalizedSlot)
+			bm.isSynced.Store(true)
+		}
+	}
+
+	return bm.isSynced.Load()
 }
 
 func (bm *BeaconMonitor) ScanProposer(startSlot uint64, finalizedEpoch uint64) (uint64, error) {
-	startEpoch := slotToEpoch(startSlot)
+	startEpoch := bm.profile.SlotToEpoch(startSlot)
 
 	endEpoch := startEpoch + 200
 	if endEpoch > finalizedEpoch {
@@ -154,7 +243,7 @@ func (bm *BeaconMonitor) ScanProposer(startSlot uint64, finalizedEpoch uint64) (
 
 	fetchBlocks, fetchErrors := bm.fetchBeaconBlocks(ctx, startEpoch, endEpoch, 50)
 
-	lastProcessedSlot, err := bm.handleBlocks(fetchBlocks)
+	lastProcessedSlot, _, err := bm.handleBlocks(fetchBlocks)
 	if err != nil {
 		return lastProcessedSlot, err
 	}
@@ -163,7 +252,7 @@ func (bm *BeaconMonitor) ScanProposer(startSlot uint64, finalizedEpoch uint64) (
 		return lastProcessedSlot, err
 	}
 
-	return (endEpoch + 1) * 32, nil
+	return bm.profile.EpochStartSlot(endEpoch + 1), nil
 }
 
 func (bm *BeaconMonitor) ValidatorMonitorLoop() {
@@ -184,7 +273,7 @@ func (bm *BeaconMonitor) ValidatorMonitorLoop() {
 				continue
 			}
 
-			curEpoch := slotToEpoch(slot)
+			curEpoch := bm.profile.SlotToEpoch(slot)
 			if curEpoch-1 <= bm.lastValidatorMonitorEpoch {
 				continue
 			}
@@ -193,8 +282,8 @@ func (bm *BeaconMonitor) ValidatorMonitorLoop() {
 			log.Infow("ValidatorMonitorLoop", "epoch", bm.lastValidatorMonitorEpoch, "curEpoch", curEpoch, "slot", slot)
 
 			if slot != lastSlot {
-				nextEpochStartSlot := (curEpoch + 1) * 32
-				timeToNextEpoch := time.Duration(nextEpochStartSlot-slot) * 12 * time.Second
+				nextEpochStartSlot := bm.profile.EpochStartSlot(curEpoch + 1)
+				timeToNextEpoch := time.Duration(nextEpochStartSlot-slot) * bm.profile.SlotDuration()
 				log.Infow("ValidatorMonitorLoop: next ticker", "reset", timeToNextEpoch.String())
 				ticker.Reset(timeToNextEpoch)
 				lastSlot = slot
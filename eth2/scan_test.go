@@ -33,7 +33,7 @@ func initBeaconMonitor(t *testing.T) *BeaconMonitor {
 	if err != nil {
 		t.Fatal(err)
 	}
-	bm, err := NewBeaconMonitor(cfg, beaconClient, db, alarmDaemon)
+	bm, err := NewBeaconMonitor(cfg, beaconClient, eth1Client, db, alarmDaemon)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,186 @@
+package eth2
+
+import (
+	"errors"
+	"fmt"
+	"github.com/monitorssv/monitorssv/alert"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// checkSlashings inspects the slashing operations in the blocks of the epoch and alerts the SSV clusters that were hit.
+// The slashings are confirmed with the beacon quorum like the ones validatorMonitor finds in the beacon state, and
+// whichever of the two records a validator as slashed first alerts it.
+func (bm *BeaconMonitor) checkSlashings(epoch uint64, blocks map[uint64]*client.StandardV2BlockResponse) error {
+	// validator index -> slot of the block that included the evidence
+	slashed := make(map[uint64]uint64)
+	for slot, block := range blocks {
+		for _, index := range slashedIndices(block) {
+			if first, ok := slashed[index]; !ok || slot < first {
+				slashed[index] = slot
+			}
+		}
+	}
+
+	if len(slashed) == 0 {
+		return nil
+	}
+
+	indices := make([]uint64, 0, len(slashed))
+	for index := range slashed {
+		indices = append(indices, index)
+	}
+	log.Infow("checkSlashings: slashing operations included", "epoch", epoch, "indices", indices)
+
+	validators, err := bm.store.GetValidatorsByValidatorIndices(indices)
+	if err != nil {
+		log.Errorw("GetValidatorsByValidatorIndices", "err", err)
+		return err
+	}
+
+	var ssvIndices []uint64
+	for _, v := range validators {
+		if !v.IsSlashed {
+			ssvIndices = append(ssvIndices, uint64(v.ValidatorIndex))
+		}
+	}
+	if len(ssvIndices) == 0 {
+		return nil
+	}
+
+	confirmed, err := bm.client.ConfirmValidatorsByIndex(bm.profile.EpochLastSlot(epoch), ssvIndices)
+	if err != nil {
+		// validatorMonitor records the slashing once the beacon nodes agree on it
+		log.Warnw("checkSlashings: ConfirmValidatorsByIndex", "epoch", epoch, "err", err)
+		return nil
+	}
+
+	hits := make(map[string]*alert.ValidatorSlashingEvidenceNotify)
+	clusterOperators := make(map[string]string)
+	for _, v := range validators {
+		entry, ok := confirmed[fmt.Sprintf("0x%s", v.PublicKey)]
+		if !ok || !entry.Validator.Slashed {
+			continue
+		}
+
+		log.Infow("ValidatorSlash", "pubKey", v.PublicKey, "index", v.ValidatorIndex)
+		marked, err := bm.store.ValidatorSlash(v.PublicKey)
+		if err != nil {
+			log.Errorw("ValidatorSlash", "err", err)
+			return err
+		}
+		if !marked {
+			continue
+		}
+
+		hit, ok := hits[v.ClusterID]
+		if !ok {
+			hit = &alert.ValidatorSlashingEvidenceNotify{
+				Epoch:       epoch,
+				ClusterId:   v.ClusterID,
+				OperatorIds: v.OperatorIds,
+			}
+			hits[v.ClusterID] = hit
+			clusterOperators[v.ClusterID] = v.OperatorIds
+		}
+		hit.Index = append(hit.Index, uint64(v.ValidatorIndex))
+		hit.Slots = append(hit.Slots, slashed[uint64(v.ValidatorIndex)])
+	}
+
+	for clusterId, hit := range hits {
+		hit.SharedClusters, hit.SharedOperators = sharedOperatorHits(clusterId, clusterOperators)
+		bm.validatorSlashingEvidenceAlarmChan <- *hit
+	}
+
+	return nil
+}
+
+// fetchEpochBlocks returns the blocks of the epoch by slot, missed slots are left out
+func (bm *BeaconMonitor) fetchEpochBlocks(epoch uint64) (map[uint64]*client.StandardV2BlockResponse, error) {
+	blocks := make(map[uint64]*client.StandardV2BlockResponse, bm.profile.SlotsPerEpoch)
+	for slot := bm.profile.EpochStartSlot(epoch); slot <= bm.profile.EpochLastSlot(epoch); slot++ {
+		block, err := bm.client.GetSlotBlock(slot)
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			log.Warnw("fetchEpochBlocks: GetSlotBlock", "slot", slot, "err", err)
+			return nil, err
+		}
+		blocks[slot] = block
+	}
+	return blocks, nil
+}
+
+// slotBlock takes the block from the blocks fetched for the epoch, it fetches it when there are none
+func (bm *BeaconMonitor) slotBlock(blocks map[uint64]*client.StandardV2BlockResponse, slot uint64) (*client.StandardV2BlockResponse, error) {
+	if blocks == nil {
+		return bm.client.GetSlotBlock(slot)
+	}
+	if block, ok := blocks[slot]; ok {
+		return block, nil
+	}
+	return nil, client.ErrNotFound
+}
+
+// slashedIndices returns the proposers of conflicting headers and the validators that signed both conflicting attestations
+func slashedIndices(block *client.StandardV2BlockResponse) []uint64 {
+	var res []uint64
+	body := block.Data.Message.Body
+	for _, slashing := range body.ProposerSlashings {
+		res = append(res, uint64(slashing.SignedHeader1.Message.ProposerIndex))
+	}
+
+	for _, slashing := range body.AttesterSlashings {
+		attesting := make(map[uint64]bool)
+		for _, index := range slashing.Attestation1.AttestingIndices {
+			attesting[uint64(index)] = true
+		}
+		for _, index := range slashing.Attestation2.AttestingIndices {
+			if attesting[uint64(index)] {
+				res = append(res, uint64(index))
+			}
+		}
+	}
+	return res
+}
+
+// sharedOperatorHits returns the other hit clusters that share operators with clusterId, and those shared operators.
+// Several clusters slashed through the same operator point to a faulty operator rather than a single validator.
+func sharedOperatorHits(clusterId string, clusterOperators map[string]string) ([]string, []uint64) {
+	operators := make(map[string]bool)
+	for _, id := range strings.Split(clusterOperators[clusterId], ",") {
+		operators[id] = true
+	}
+
+	var clusters []string
+	shared := make(map[string]bool)
+	for otherId, otherOperators := range clusterOperators {
+		if otherId == clusterId {
+			continue
+		}
+		hit := false
+		for _, id := range strings.Split(otherOperators, ",") {
+			if operators[id] {
+				shared[id] = true
+				hit = true
+			}
+		}
+		if hit {
+			clusters = append(clusters, otherId)
+		}
+	}
+
+	sharedOperators := make([]uint64, 0, len(shared))
+	for id := range shared {
+		operatorId, _ := strconv.ParseUint(id, 10, 64)
+		sharedOperators = append(sharedOperators, operatorId)
+	}
+	sort.Strings(clusters)
+	sort.Slice(sharedOperators, func(i, j int) bool {
+		return sharedOperators[i] < sharedOperators[j]
+	})
+	return clusters, sharedOperators
+}
@@ -0,0 +1,44 @@
+package eth2
+
+import (
+	"encoding/json"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSlashedIndices(t *testing.T) {
+	data := `{"data":{"message":{"slot":"100","body":{
+		"proposer_slashings":[{"signed_header_1":{"message":{"proposer_index":"7"}},"signed_header_2":{"message":{"proposer_index":"7"}}}],
+		"attester_slashings":[{"attestation_1":{"attesting_indices":["1","2","3"]},"attestation_2":{"attesting_indices":["2","3","4"]}}]
+	}}}}`
+	var block client.StandardV2BlockResponse
+	if err := json.Unmarshal([]byte(data), &block); err != nil {
+		t.Fatal(err)
+	}
+
+	indices := slashedIndices(&block)
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	if !reflect.DeepEqual(indices, []uint64{2, 3, 7}) {
+		t.Fatalf("unexpected slashed indices: %v", indices)
+	}
+}
+
+func TestSharedOperatorHits(t *testing.T) {
+	clusterOperators := map[string]string{
+		"a": "1,2,3,4",
+		"b": "4,5,6,7",
+		"c": "8,9,10,11",
+	}
+
+	clusters, operators := sharedOperatorHits("a", clusterOperators)
+	if !reflect.DeepEqual(clusters, []string{"b"}) || !reflect.DeepEqual(operators, []uint64{4}) {
+		t.Fatalf("unexpected shared hits: %v %v", clusters, operators)
+	}
+
+	clusters, operators = sharedOperatorHits("c", clusterOperators)
+	if len(clusters) != 0 || len(operators) != 0 {
+		t.Fatalf("unexpected shared hits: %v %v", clusters, operators)
+	}
+}
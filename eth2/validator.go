@@ -0,0 +1,42 @@
+package eth2
+
+import "fmt"
+
+// ValidatorState is the beacon state of a validator at the head, balances in gwei
+type ValidatorState struct {
+	Slot             uint64 `json:"slot"`
+	Index            uint64 `json:"index"`
+	Status           string `json:"status"`
+	Balance          uint64 `json:"balance"`
+	EffectiveBalance uint64 `json:"effectiveBalance"`
+	Slashed          bool   `json:"slashed"`
+}
+
+// GetValidatorState returns nil if the validator is not in the beacon state yet
+func (bm *BeaconMonitor) GetValidatorState(pubKey string) (*ValidatorState, error) {
+	slot, err := bm.client.GetLatestSlot()
+	if err != nil {
+		log.Warnw("GetValidatorState: GetLatestSlot", "err", err)
+		return nil, err
+	}
+
+	pubKey = fmt.Sprintf("0x%s", removePubKeyPrefix(pubKey))
+	validators, err := bm.client.GetSlotValidatorsByPubKey(slot, []string{pubKey})
+	if err != nil {
+		log.Warnw("GetValidatorState: GetSlotValidatorsByPubKey", "pubKey", pubKey, "err", err)
+		return nil, err
+	}
+
+	entry, ok := validators[pubKey]
+	if !ok {
+		return nil, nil
+	}
+	return &ValidatorState{
+		Slot:             slot,
+		Index:            uint64(entry.Index),
+		Status:           entry.Status,
+		Balance:          uint64(entry.Balance),
+		EffectiveBalance: uint64(entry.Validator.EffectiveBalance),
+		Slashed:          entry.Validator.Slashed,
+	}, nil
+}
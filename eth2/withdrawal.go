@@ -0,0 +1,303 @@
+package eth2
+
+import (
+	"errors"
+	"github.com/monitorssv/monitorssv/alert"
+	"github.com/monitorssv/monitorssv/eth2/client"
+	"github.com/monitorssv/monitorssv/store"
+	"math"
+)
+
+const (
+	farFutureEpoch = math.MaxUint64
+	// maxWithdrawalsPerPayload is the number of withdrawals a block sweeps at most
+	maxWithdrawalsPerPayload = 16
+	// maxSweepSearchEpochs limits how far back the blocks are searched for a full withdrawal
+	maxSweepSearchEpochs = 4
+	// the validator registry size is only needed for the sweep estimate, refresh it about once a day
+	validatorCountRefreshEpochs = 225
+)
+
+// withdrawalSweep is the position of the withdrawal sweep: the validator index of the last withdrawal in the block at Slot
+type withdrawalSweep struct {
+	Slot           uint64
+	ValidatorIndex uint64
+}
+
+type fullWithdrawal struct {
+	Slot        uint64
+	BlockNumber uint64
+	Amount      uint64
+}
+
+// WithdrawalTimeline times are unix seconds, the sweep is estimated until Swept is set
+type WithdrawalTimeline struct {
+	ExitEpoch         uint64 `json:"exitEpoch"`
+	ExitTime          int64  `json:"exitTime"`
+	WithdrawableEpoch uint64 `json:"withdrawableEpoch"`
+	WithdrawableTime  int64  `json:"withdrawableTime"`
+	// SweepSlot is 0 if it can not be estimated yet
+	SweepSlot   uint64 `json:"sweepSlot"`
+	SweepTime   int64  `json:"sweepTime"`
+	Swept       bool   `json:"swept"`
+	SweepBlock  uint64 `json:"sweepBlock"`
+	SweepAmount uint64 `json:"sweepAmount"`
+}
+
+// trackExit records the exit and withdrawable epochs, and collects the validators whose balance was swept
+func (bm *BeaconMonitor) trackExit(v *store.ValidatorInfo, validatorInfo *client.StandardValidatorEntry, swept map[uint64]*store.ValidatorInfo) error {
+	exitEpoch := uint64(validatorInfo.Validator.ExitEpoch)
+	withdrawableEpoch := uint64(validatorInfo.Validator.WithdrawableEpoch)
+	if exitEpoch == 0 || exitEpoch == farFutureEpoch {
+		return nil
+	}
+
+	pubKey := removePubKeyPrefix(validatorInfo.Validator.Pubkey)
+	if v.ExitEpoch != exitEpoch || v.WithdrawableEpoch != withdrawableEpoch {
+		log.Infow("UpdateValidatorExitEpoch", "pubKey", pubKey, "exitEpoch", exitEpoch, "withdrawableEpoch", withdrawableEpoch)
+		err := bm.store.UpdateValidatorExitEpoch(pubKey, exitEpoch, withdrawableEpoch)
+		if err != nil {
+			log.Errorw("UpdateValidatorExitEpoch", "err", err)
+			return err
+		}
+
+		if v.ExitEpoch == 0 && validatorInfo.Status == store.WithdrawalDone {
+			// swept before it was tracked, the withdrawal block is unknown
+			return bm.store.ValidatorWithdrawn(pubKey, bm.profile.EpochStartSlot(withdrawableEpoch), 0, 0)
+		}
+		v.ExitEpoch = exitEpoch
+		v.WithdrawableEpoch = withdrawableEpoch
+	}
+
+	if validatorInfo.Status == store.WithdrawalDone {
+		swept[uint64(validatorInfo.Index)] = v
+	}
+	return nil
+}
+
+// handleSweptValidators finds the full withdrawal of every swept validator and notifies the owner.
+// A validator whose withdrawal is not in the recent blocks stays pending, each later epoch searches further back.
+func (bm *BeaconMonitor) handleSweptValidators(epoch uint64, swept map[uint64]*store.ValidatorInfo) error {
+	if len(swept) == 0 {
+		return nil
+	}
+
+	fromEpoch := uint64(0)
+	if epoch >= maxSweepSearchEpochs {
+		fromEpoch = epoch - maxSweepSearchEpochs + 1
+	}
+	minWithdrawableEpoch := uint64(farFutureEpoch)
+	for _, v := range swept {
+		minWithdrawableEpoch = min(minWithdrawableEpoch, v.WithdrawableEpoch)
+	}
+	fromEpoch = max(fromEpoch, minWithdrawableEpoch)
+
+	withdrawals, err := bm.findFullWithdrawals(fromEpoch, epoch, swept)
+	if err != nil {
+		log.Warnw("findFullWithdrawals", "err", err)
+		return err
+	}
+
+	for index, v := range swept {
+		withdrawal, ok := withdrawals[index]
+		if !ok {
+			withdrawal, ok, err = bm.searchOlderWithdrawal(index, v, fromEpoch)
+			if err != nil {
+				log.Warnw("searchOlderWithdrawal", "index", index, "err", err)
+				return err
+			}
+		}
+		if !ok {
+			log.Warnw("handleSweptValidators: full withdrawal not found, retry on the next epoch", "index", index, "withdrawableEpoch", v.WithdrawableEpoch, "toEpoch", epoch)
+			continue
+		}
+		delete(bm.sweepSearchedEpochs, index)
+
+		log.Infow("ValidatorWithdrawn", "pubKey", v.PublicKey, "index", index, "slot", withdrawal.Slot, "block", withdrawal.BlockNumber, "amount", withdrawal.Amount)
+		err = bm.store.ValidatorWithdrawn(v.PublicKey, withdrawal.Slot, withdrawal.BlockNumber, withdrawal.Amount)
+		if err != nil {
+			log.Errorw("ValidatorWithdrawn", "err", err)
+			return err
+		}
+
+		bm.validatorWithdrawnAlarmChan <- alert.ValidatorWithdrawnNotify{
+			ClusterId:   v.ClusterID,
+			Index:       index,
+			Slot:        withdrawal.Slot,
+			BlockNumber: withdrawal.BlockNumber,
+			Amount:      withdrawal.Amount,
+		}
+	}
+
+	return nil
+}
+
+// searchOlderWithdrawal searches the maxSweepSearchEpochs epochs before the ones already searched for the validator,
+// it starts over from recentEpoch once the withdrawable epoch was reached without finding the withdrawal
+func (bm *BeaconMonitor) searchOlderWithdrawal(index uint64, v *store.ValidatorInfo, recentEpoch uint64) (fullWithdrawal, bool, error) {
+	searchedEpoch, ok := bm.sweepSearchedEpochs[index]
+	if !ok || searchedEpoch > recentEpoch {
+		searchedEpoch = recentEpoch
+	}
+	fromEpoch, toEpoch, ok := olderSweepWindow(searchedEpoch, v.WithdrawableEpoch)
+	if !ok {
+		delete(bm.sweepSearchedEpochs, index)
+		return fullWithdrawal{}, false, nil
+	}
+
+	withdrawals, err := bm.findFullWithdrawals(fromEpoch, toEpoch, map[uint64]*store.ValidatorInfo{index: v})
+	if err != nil {
+		return fullWithdrawal{}, false, err
+	}
+	bm.sweepSearchedEpochs[index] = fromEpoch
+	withdrawal, ok := withdrawals[index]
+	return withdrawal, ok, nil
+}
+
+// olderSweepWindow returns the epochs before searchedEpoch that have not been searched yet, not before the withdrawable epoch
+func olderSweepWindow(searchedEpoch, withdrawableEpoch uint64) (uint64, uint64, bool) {
+	if searchedEpoch <= withdrawableEpoch {
+		return 0, 0, false
+	}
+	toEpoch := searchedEpoch - 1
+	fromEpoch := withdrawableEpoch
+	if toEpoch-withdrawableEpoch >= maxSweepSearchEpochs {
+		fromEpoch = toEpoch - maxSweepSearchEpochs + 1
+	}
+	return fromEpoch, toEpoch, true
+}
+
+// findFullWithdrawals searches the blocks backwards, so the last withdrawal of a validator is taken
+func (bm *BeaconMonitor) findFullWithdrawals(fromEpoch, toEpoch uint64, swept map[uint64]*store.ValidatorInfo) (map[uint64]fullWithdrawal, error) {
+	res := make(map[uint64]fullWithdrawal)
+	startSlot := bm.profile.EpochStartSlot(fromEpoch)
+	for slot := bm.profile.EpochLastSlot(toEpoch) + 1; slot > startSlot && len(res) < len(swept); slot-- {
+		block, err := bm.client.GetSlotBlock(slot - 1)
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		payload := block.Data.Message.Body.ExecutionPayload
+		if payload == nil {
+			continue
+		}
+		for _, withdrawal := range payload.Withdrawals {
+			index := uint64(withdrawal.ValidatorIndex)
+			if _, ok := swept[index]; !ok {
+				continue
+			}
+			if _, ok := res[index]; ok {
+				continue
+			}
+			res[index] = fullWithdrawal{
+				Slot:        slot - 1,
+				BlockNumber: uint64(payload.BlockNumber),
+				Amount:      uint64(withdrawal.Amount),
+			}
+		}
+	}
+	return res, nil
+}
+
+// updateSweepPosition samples the withdrawal sweep from the last block of the epoch
+func (bm *BeaconMonitor) updateSweepPosition(epoch uint64) {
+	slot := bm.profile.EpochLastSlot(epoch)
+	block, err := bm.client.GetSlotBlock(slot)
+	if err != nil {
+		if !errors.Is(err, client.ErrNotFound) {
+			log.Warnw("updateSweepPosition: GetSlotBlock", "slot", slot, "err", err)
+		}
+		return
+	}
+
+	payload := block.Data.Message.Body.ExecutionPayload
+	if payload == nil || len(payload.Withdrawals) == 0 {
+		return
+	}
+
+	bm.sweepMu.RLock()
+	refresh := bm.validatorCount == 0 || epoch >= bm.validatorCountEpoch+validatorCountRefreshEpochs
+	bm.sweepMu.RUnlock()
+
+	var validatorCount uint64
+	if refresh {
+		validatorCount, err = bm.client.GetValidatorCount(slot)
+		if err != nil {
+			log.Warnw("updateSweepPosition: GetValidatorCount", "slot", slot, "err", err)
+		}
+	}
+
+	bm.sweepMu.Lock()
+	defer bm.sweepMu.Unlock()
+	if validatorCount != 0 {
+		bm.validatorCount = validatorCount
+		bm.validatorCountEpoch = epoch
+	}
+	bm.prevSweep = bm.sweep
+	bm.sweep = withdrawalSweep{
+		Slot:           slot,
+		ValidatorIndex: uint64(payload.Withdrawals[len(payload.Withdrawals)-1].ValidatorIndex),
+	}
+	log.Infow("updateSweepPosition", "sweep", bm.sweep, "validatorCount", bm.validatorCount)
+}
+
+// WithdrawalTimeline returns nil for validators that have not initiated an exit
+func (bm *BeaconMonitor) WithdrawalTimeline(v *store.ValidatorInfo) *WithdrawalTimeline {
+	if v.ExitEpoch == 0 {
+		return nil
+	}
+
+	timeline := &WithdrawalTimeline{
+		ExitEpoch:         v.ExitEpoch,
+		ExitTime:          bm.profile.EpochTime(v.ExitEpoch).Unix(),
+		WithdrawableEpoch: v.WithdrawableEpoch,
+		WithdrawableTime:  bm.profile.EpochTime(v.WithdrawableEpoch).Unix(),
+	}
+
+	if v.WithdrawnSlot != 0 {
+		timeline.Swept = true
+		timeline.SweepSlot = v.WithdrawnSlot
+		timeline.SweepBlock = v.WithdrawnBlock
+		timeline.SweepAmount = v.WithdrawnAmount
+	} else if v.ValidatorIndex != store.DefaultValidatorIndex {
+		bm.sweepMu.RLock()
+		rate := sweepRate(bm.prevSweep, bm.sweep, bm.validatorCount)
+		timeline.SweepSlot = estimateSweepSlot(bm.sweep, rate, bm.validatorCount, uint64(v.ValidatorIndex), bm.profile.EpochStartSlot(v.WithdrawableEpoch))
+		bm.sweepMu.RUnlock()
+	}
+
+	if timeline.SweepSlot != 0 {
+		timeline.SweepTime = bm.profile.SlotTime(timeline.SweepSlot).Unix()
+	}
+	return timeline
+}
+
+// sweepRate returns how many validator indices the sweep advances per slot.
+// Validators without anything to withdraw are skipped, so the sweep is usually faster than one payload per slot.
+func sweepRate(prev, cur withdrawalSweep, validatorCount uint64) float64 {
+	if prev.Slot == 0 || cur.Slot <= prev.Slot || validatorCount == 0 {
+		return maxWithdrawalsPerPayload
+	}
+
+	distance := (cur.ValidatorIndex + validatorCount - prev.ValidatorIndex) % validatorCount
+	return max(float64(distance)/float64(cur.Slot-prev.Slot), maxWithdrawalsPerPayload)
+}
+
+// estimateSweepSlot returns the first slot after withdrawableSlot in which the sweep reaches the validator, 0 if unknown
+func estimateSweepSlot(sweep withdrawalSweep, rate float64, validatorCount, validatorIndex, withdrawableSlot uint64) uint64 {
+	if sweep.Slot == 0 || validatorCount == 0 || validatorIndex >= validatorCount {
+		return 0
+	}
+
+	distance := (validatorIndex + validatorCount - sweep.ValidatorIndex) % validatorCount
+	slot := sweep.Slot + uint64(math.Ceil(float64(distance)/rate))
+	if slot < withdrawableSlot {
+		// the sweep passes the validator once per cycle
+		cycle := uint64(math.Ceil(float64(validatorCount) / rate))
+		slot += (withdrawableSlot - slot + cycle - 1) / cycle * cycle
+	}
+	return slot
+}
@@ -0,0 +1,63 @@
+package eth2
+
+import "testing"
+
+func TestEstimateSweepSlot(t *testing.T) {
+	sweep := withdrawalSweep{Slot: 1000, ValidatorIndex: 500}
+
+	rate := sweepRate(withdrawalSweep{}, sweep, 1000)
+	if rate != maxWithdrawalsPerPayload {
+		t.Fatalf("expected default rate, got %v", rate)
+	}
+	rate = sweepRate(withdrawalSweep{Slot: 990, ValidatorIndex: 100}, sweep, 1000)
+	if rate != 40 {
+		t.Fatalf("expected rate 40, got %v", rate)
+	}
+	// wrapped around the registry
+	rate = sweepRate(withdrawalSweep{Slot: 990, ValidatorIndex: 900}, sweep, 1000)
+	if rate != 60 {
+		t.Fatalf("expected rate 60, got %v", rate)
+	}
+
+	tests := []struct {
+		index            uint64
+		withdrawableSlot uint64
+		expected         uint64
+	}{
+		{index: 900, withdrawableSlot: 0, expected: 1010},
+		{index: 100, withdrawableSlot: 0, expected: 1015},
+		// not withdrawable yet when the sweep passes, wait another cycle of 25 slots
+		{index: 900, withdrawableSlot: 1011, expected: 1035},
+		{index: 900, withdrawableSlot: 1060, expected: 1060},
+		{index: 1000, withdrawableSlot: 0, expected: 0},
+	}
+	for _, test := range tests {
+		slot := estimateSweepSlot(sweep, 40, 1000, test.index, test.withdrawableSlot)
+		if slot != test.expected {
+			t.Fatalf("index %d: expected %d, got %d", test.index, test.expected, slot)
+		}
+	}
+
+	if estimateSweepSlot(withdrawalSweep{}, 40, 1000, 1, 0) != 0 {
+		t.Fatal("expected unknown estimate without sweep sample")
+	}
+}
+
+func TestOlderSweepWindow(t *testing.T) {
+	tests := []struct {
+		searched, withdrawable uint64
+		from, to               uint64
+		ok                     bool
+	}{
+		{searched: 100, withdrawable: 50, from: 96, to: 99, ok: true},
+		{searched: 52, withdrawable: 50, from: 50, to: 51, ok: true},
+		{searched: 50, withdrawable: 50},
+		{searched: 40, withdrawable: 50},
+	}
+	for _, test := range tests {
+		from, to, ok := olderSweepWindow(test.searched, test.withdrawable)
+		if from != test.from || to != test.to || ok != test.ok {
+			t.Fatalf("searched %d: expected %d-%d %v, got %d-%d %v", test.searched, test.from, test.to, test.ok, from, to, ok)
+		}
+	}
+}
@@ -11,6 +11,9 @@ type Block struct {
 	Epoch       uint64 `json:"epoch"`
 	Slot        uint64 `json:"slot"`
 	BlockNumber uint64 `json:"blockNumber"`
+	IsMissed    bool   `json:"isMissed"`
+	// MissedReason is "no_block" or "orphaned" for missed blocks
+	MissedReason string `json:"missedReason,omitempty"`
 }
 
 func (ms *MonitorSSV) GetBlocks(c *gin.Context) {
@@ -47,10 +50,12 @@ func (ms *MonitorSSV) GetBlocks(c *gin.Context) {
 	var blocks = make([]Block, 0)
 	for _, blockInfo := range blockInfos {
 		blocks = append(blocks, Block{
-			Proposer:    blockInfo.Proposer,
-			Epoch:       blockInfo.Epoch,
-			Slot:        blockInfo.Slot,
-			BlockNumber: blockInfo.BlockNumber,
+			Proposer:     blockInfo.Proposer,
+			Epoch:        blockInfo.Epoch,
+			Slot:         blockInfo.Slot,
+			BlockNumber:  blockInfo.BlockNumber,
+			IsMissed:     blockInfo.IsMissed,
+			MissedReason: blockInfo.MissedReason,
 		})
 	}
 
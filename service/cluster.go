@@ -3,12 +3,14 @@ package service
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/monitorssv/monitorssv/eth1/ssv"
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"github.com/monitorssv/monitorssv/store"
 	"math"
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Cluster struct {
@@ -306,18 +308,43 @@ func (ms *MonitorSSV) GetClusters(c *gin.Context) {
 }
 
 type ClusterDetails struct {
-	ID                         string          `json:"id"`
-	Owner                      string          `json:"owner"`
-	FeeRecipientAddress        string          `json:"feeRecipientAddress"`
-	Active                     bool            `json:"active"`
-	OnChainBalance             string          `json:"onChainBalance"`
-	BurnFee                    string          `json:"burnFee"`
-	OperationalRunaway         uint64          `json:"operationalRunaway"`
-	UpcomingBurnFee            string          `json:"upcomingBurnFee"`
-	UpcomingOperationalRunaway uint64          `json:"upcomingOperationalRunaway"`
-	UpcomingCalcTime           int64           `json:"upcomingCalcTime"`
-	ValidatorCount             uint32          `json:"validatorCount"`
-	Operators                  []OperatorIntro `json:"operators"`
+	ID                         string            `json:"id"`
+	Owner                      string            `json:"owner"`
+	FeeRecipientAddress        string            `json:"feeRecipientAddress"`
+	Active                     bool              `json:"active"`
+	OnChainBalance             string            `json:"onChainBalance"`
+	BurnFee                    string            `json:"burnFee"`
+	OperationalRunaway         uint64            `json:"operationalRunaway"`
+	UpcomingBurnFee            string            `json:"upcomingBurnFee"`
+	UpcomingOperationalRunaway uint64            `json:"upcomingOperationalRunaway"`
+	UpcomingCalcTime           int64             `json:"upcomingCalcTime"`
+	ValidatorCount             uint32            `json:"validatorCount"`
+	Operators                  []OperatorIntro   `json:"operators"`
+	Projection                 *RunwayProjection `json:"projection"`
+}
+
+// RunwayProjection is the projected balance of a cluster with the scheduled fee changes applied,
+// the balance is linear between two points and the times are estimated from the blocks
+type RunwayProjection struct {
+	LiquidationBlock   uint64               `json:"liquidationBlock"`
+	OperationalRunaway uint64               `json:"operationalRunaway"`
+	Points             []RunwayPoint        `json:"points"`
+	Changes            []ScheduledFeeChange `json:"changes"`
+}
+
+type RunwayPoint struct {
+	Block   uint64 `json:"block"`
+	Time    int64  `json:"time"`
+	Balance string `json:"balance"`
+	BurnFee string `json:"burnFee"`
+}
+
+// ScheduledFeeChange OperatorId is 0 for the network fee
+type ScheduledFeeChange struct {
+	Block      uint64 `json:"block"`
+	Time       int64  `json:"time"`
+	OperatorId uint64 `json:"operatorId"`
+	Fee        string `json:"fee"`
 }
 
 func (ms *MonitorSSV) GetClusterDetails(c *gin.Context) {
@@ -394,6 +421,15 @@ func (ms *MonitorSSV) GetClusterDetails(c *gin.Context) {
 	}
 	clusterDetails.Operators = operators
 
+	if clusterInfo.Active && clusterInfo.ValidatorCount != 0 {
+		projection, err := ms.ssv.ProjectStoredClusterRunway(*clusterInfo)
+		if err != nil {
+			monitorLog.Warnw("GetClusterDetails: ProjectStoredClusterRunway", "clusterId", clusterId, "err", err.Error())
+		} else {
+			clusterDetails.Projection = newRunwayProjection(projection)
+		}
+	}
+
 	ReturnOk(c, gin.H{
 		"clusterDetails": clusterDetails,
 	})
@@ -407,3 +443,41 @@ type ClusterReward struct {
 	TotalRewards        string `json:"totalRewards"`
 	TotalPenalties      string `json:"totalPenalties"`
 }
+
+func newRunwayProjection(projection *ssv.RunwayProjection) *RunwayProjection {
+	// the projection starts at the chain head
+	curBlock := projection.Points[0].Block
+	now := time.Now().Unix()
+	blockTime := func(block uint64) int64 {
+		return now + (int64(block)-int64(curBlock))*12
+	}
+	annualFee := func(fee uint64) string {
+		return utils.ToSSV(big.NewInt(0).Mul(big.NewInt(0).SetUint64(fee), big.NewInt(2613400)), "%.2f")
+	}
+
+	res := &RunwayProjection{
+		LiquidationBlock: projection.LiquidationBlock,
+		Points:           make([]RunwayPoint, 0, len(projection.Points)),
+		Changes:          make([]ScheduledFeeChange, 0, len(projection.Changes)),
+	}
+	if projection.LiquidationBlock > curBlock {
+		res.OperationalRunaway = projection.LiquidationBlock - curBlock
+	}
+	for _, point := range projection.Points {
+		res.Points = append(res.Points, RunwayPoint{
+			Block:   point.Block,
+			Time:    blockTime(point.Block),
+			Balance: utils.ToSSV(point.Balance, "%.2f"),
+			BurnFee: annualFee(point.Fee),
+		})
+	}
+	for _, change := range projection.Changes {
+		res.Changes = append(res.Changes, ScheduledFeeChange{
+			Block:      change.Block,
+			Time:       blockTime(change.Block),
+			OperatorId: change.OperatorId,
+			Fee:        annualFee(change.Fee),
+		})
+	}
+	return res
+}
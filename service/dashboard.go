@@ -31,7 +31,7 @@ func (ms *MonitorSSV) Dashboard(c *gin.Context) {
 
 	var proposedBlocks int64
 	var activeClusterCount int64
-	if ms.ssv.GetCfg().Network == "mainnet" {
+	if ms.beaconMonitor.Enabled() {
 		proposedBlocks, err = ms.store.GetTotalBlockCount()
 		if err != nil {
 			monitorLog.Errorw("Dashboard:GetTotalBlockCount", "err", err)
@@ -70,7 +70,7 @@ func (ms *MonitorSSV) Dashboard(c *gin.Context) {
 
 	var dashboardData DashboardData
 
-	if ms.ssv.GetCfg().Network == "mainnet" {
+	if ms.beaconMonitor.Enabled() {
 		latestBlocks, err := ms.store.GetLatestBlocks()
 		if err != nil {
 			monitorLog.Errorw("Dashboard:GetLatestBlocks", "err", err)
@@ -81,10 +81,12 @@ func (ms *MonitorSSV) Dashboard(c *gin.Context) {
 		var blocks = make([]Block, 0)
 		for _, block := range latestBlocks {
 			blocks = append(blocks, Block{
-				Proposer:    block.Proposer,
-				Epoch:       block.Epoch,
-				Slot:        block.Slot,
-				BlockNumber: block.BlockNumber,
+				Proposer:     block.Proposer,
+				Epoch:        block.Epoch,
+				Slot:         block.Slot,
+				BlockNumber:  block.BlockNumber,
+				IsMissed:     block.IsMissed,
+				MissedReason: block.MissedReason,
 			})
 		}
 		dashboardData.Blocks = blocks
@@ -132,6 +134,7 @@ func (ms *MonitorSSV) Dashboard(c *gin.Context) {
 			BlockNumber: event.BlockNumber,
 			TxHash:      event.TxHash,
 			Action:      event.Action,
+			Provisional: event.Provisional,
 		})
 	}
 	dashboardData.Events = events
@@ -12,6 +12,8 @@ type Event struct {
 	BlockNumber uint64 `json:"block"`
 	TxHash      string `json:"transactionHash"`
 	Action      string `json:"action"`
+	// Provisional events are above the finalized block and may still be reorged
+	Provisional bool `json:"provisional,omitempty"`
 }
 
 func (ms *MonitorSSV) GetEvents(c *gin.Context) {
@@ -65,6 +67,7 @@ func (ms *MonitorSSV) GetEvents(c *gin.Context) {
 			BlockNumber: eventInfo.BlockNumber,
 			TxHash:      eventInfo.TxHash,
 			Action:      eventInfo.Action,
+			Provisional: eventInfo.Provisional,
 		})
 	}
 
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ClusterLedgerEntry struct {
+	Block          uint64 `json:"block"`
+	Time           int64  `json:"time"`
+	Action         string `json:"action"`
+	Amount         string `json:"amount"`
+	Burn           string `json:"burn"`
+	Balance        string `json:"balance"`
+	ValidatorCount uint32 `json:"validatorCount"`
+	TxHash         string `json:"txHash"`
+}
+
+// GetClusterLedger returns the balance changes of a cluster, format=csv downloads them with the amounts in wei as well
+func (ms *MonitorSSV) GetClusterLedger(c *gin.Context) {
+	clusterId := c.DefaultQuery("clusterId", "")
+	if len(clusterId) != clusterIdLength {
+		monitorLog.Warnw("GetClusterLedger", "clusterId", clusterId)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		monitorLog.Warnw("GetClusterLedger", "format", format)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	monitorLog.Infow("GetClusterLedger", "clusterId", clusterId, "format", format)
+
+	ledgerInfos, err := ms.store.GetClusterLedger(clusterId)
+	if err != nil {
+		monitorLog.Errorw("GetClusterLedger: GetClusterLedger", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=cluster-%s-ledger.csv", clusterId))
+		c.Status(http.StatusOK)
+
+		w := csv.NewWriter(c.Writer)
+		_ = w.Write([]string{"block", "time", "action", "amount_ssv", "burn_ssv", "balance_ssv", "amount_wei", "burn_wei", "balance_wei", "validator_count", "tx_hash"})
+		for _, info := range ledgerInfos {
+			_ = w.Write([]string{
+				strconv.FormatUint(info.BlockNumber, 10),
+				time.Unix(info.BlockTime, 0).UTC().Format(time.RFC3339),
+				info.Action,
+				weiToSSV(info.Amount),
+				weiToSSV(info.Burn),
+				weiToSSV(info.Balance),
+				info.Amount,
+				info.Burn,
+				info.Balance,
+				strconv.FormatUint(uint64(info.ValidatorCount), 10),
+				info.TxHash,
+			})
+		}
+		w.Flush()
+		if err = w.Error(); err != nil {
+			monitorLog.Warnw("GetClusterLedger: csv", "err", err.Error())
+		}
+		return
+	}
+
+	var totalDeposited, totalPaidOut, totalBurnt = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+	var ledger = make([]ClusterLedgerEntry, 0, len(ledgerInfos))
+	for _, info := range ledgerInfos {
+		if amount, ok := big.NewInt(0).SetString(info.Amount, 10); ok {
+			if amount.Sign() > 0 {
+				totalDeposited.Add(totalDeposited, amount)
+			} else {
+				totalPaidOut.Sub(totalPaidOut, amount)
+			}
+		}
+		if burn, ok := big.NewInt(0).SetString(info.Burn, 10); ok {
+			totalBurnt.Add(totalBurnt, burn)
+		}
+
+		ledger = append(ledger, ClusterLedgerEntry{
+			Block:          info.BlockNumber,
+			Time:           info.BlockTime,
+			Action:         info.Action,
+			Amount:         weiToSSV(info.Amount),
+			Burn:           weiToSSV(info.Burn),
+			Balance:        weiToSSV(info.Balance),
+			ValidatorCount: info.ValidatorCount,
+			TxHash:         info.TxHash,
+		})
+	}
+
+	ReturnOk(c, gin.H{
+		"clusterId": clusterId,
+		"ledger":    ledger,
+		"deposited": utils.ToSSV(totalDeposited, "%.9f"),
+		"paidOut":   utils.ToSSV(totalPaidOut, "%.9f"),
+		"burnt":     utils.ToSSV(totalBurnt, "%.9f"),
+	})
+}
+
+func weiToSSV(wei string) string {
+	value, ok := big.NewInt(0).SetString(wei, 10)
+	if !ok {
+		return wei
+	}
+	return utils.ToSSV(value, "%.9f")
+}
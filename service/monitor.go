@@ -57,6 +57,7 @@ type MonitorConfig struct {
 	ReportProposeBlock         bool   `json:"report_propose_block"`
 	ReportMissedBlock          bool   `json:"report_missed_block"`
 	ReportBalanceDecrease      bool   `json:"report_balance_decrease"`
+	ReportOfflineEpochs        uint64 `json:"report_offline_epochs"`
 	ReportExitedButNotRemoved  bool   `json:"report_exited_but_not_removed"`
 	ReportWeekly               bool   `json:"report_weekly"`
 }
@@ -94,15 +95,8 @@ func (ms *MonitorSSV) DeleteMonitorConfig(c *gin.Context) {
 		return
 	}
 
-	sign := common.FromHex(param.Signature)
 	msg := fmt.Sprintf(getMonitorConfigFormat, param.Block)
-	addr, err := crypto.Ecrecover([]byte(msg), sign)
-	if err != nil {
-		ReturnErr(c, badRequestRes)
-		return
-	}
-	if addr != param.Owner {
-		ReturnErr(c, badRequestRes)
+	if !ms.verifyOwnerSignature(c, param.Owner, msg, param.Signature) {
 		return
 	}
 
@@ -151,15 +145,8 @@ func (ms *MonitorSSV) GetClusterMonitorConfig(c *gin.Context) {
 		return
 	}
 
-	sign := common.FromHex(signature)
 	msg := fmt.Sprintf(getMonitorConfigFormat, block)
-	addr, err := crypto.Ecrecover([]byte(msg), sign)
-	if err != nil {
-		ReturnErr(c, badRequestRes)
-		return
-	}
-	if addr != owner {
-		ReturnErr(c, badRequestRes)
+	if !ms.verifyOwnerSignature(c, owner, msg, signature) {
 		return
 	}
 
@@ -211,6 +198,7 @@ func (ms *MonitorSSV) GetClusterMonitorConfig(c *gin.Context) {
 	mc.ReportProposeBlock = alarmInfo.ReportProposeBlock
 	mc.ReportMissedBlock = alarmInfo.ReportMissedBlock
 	mc.ReportBalanceDecrease = alarmInfo.ReportBalanceDecrease
+	mc.ReportOfflineEpochs = alarmInfo.ReportOfflineEpochs
 	mc.ReportExitedButNotRemoved = alarmInfo.ReportExitedButNotRemoved
 	mc.ReportWeekly = alarmInfo.ReportWeekly
 
@@ -253,21 +241,12 @@ func (ms *MonitorSSV) SaveClusterMonitorConfig(c *gin.Context) {
 		return
 	}
 
-	sign := common.FromHex(param.Signature)
 	msg := fmt.Sprintf(saveMonitorConfigFormat, param.Block, param.MonitorConfig)
-
-	addr, err := crypto.Ecrecover([]byte(msg), sign)
-	if err != nil {
-		ReturnErr(c, badRequestRes)
-		return
-	}
-
-	if addr != param.Owner {
-		ReturnErr(c, badRequestRes)
+	if !ms.verifyOwnerSignature(c, param.Owner, msg, param.Signature) {
 		return
 	}
 
-	_, totalActiveClusterCount, err := ms.getOwnerClusterInfo(addr)
+	_, totalActiveClusterCount, err := ms.getOwnerClusterInfo(param.Owner)
 	if err != nil {
 		monitorLog.Errorw("getOwnerClusterInfo", "err", err.Error())
 		ReturnErr(c, serverErrRes)
@@ -288,7 +267,7 @@ func (ms *MonitorSSV) SaveClusterMonitorConfig(c *gin.Context) {
 	}
 
 	info := &store.AlarmInfo{
-		EoaOwner:                   addr,
+		EoaOwner:                   param.Owner,
 		AlarmType:                  monitorConfig.AlarmType,
 		AlarmChannel:               hex.EncodeToString(encryptedData),
 		AlarmChannelHash:           hex.EncodeToString(alarmChannelHash),
@@ -298,6 +277,7 @@ func (ms *MonitorSSV) SaveClusterMonitorConfig(c *gin.Context) {
 		ReportProposeBlock:         monitorConfig.ReportProposeBlock,
 		ReportMissedBlock:          monitorConfig.ReportMissedBlock,
 		ReportBalanceDecrease:      monitorConfig.ReportBalanceDecrease,
+		ReportOfflineEpochs:        monitorConfig.ReportOfflineEpochs,
 		ReportExitedButNotRemoved:  monitorConfig.ReportExitedButNotRemoved,
 		ReportWeekly:               monitorConfig.ReportWeekly,
 	}
@@ -317,6 +297,22 @@ func (ms *MonitorSSV) SaveClusterMonitorConfig(c *gin.Context) {
 	})
 }
 
+// verifyOwnerSignature writes the error response when the signature is not valid for the owner.
+// Safe and smart account owners are verified on-chain.
+func (ms *MonitorSSV) verifyOwnerSignature(c *gin.Context, owner, msg, signature string) bool {
+	isValid, err := ms.ssv.VerifyOwnerSignature(owner, []byte(msg), common.FromHex(signature))
+	if err != nil {
+		monitorLog.Errorw("VerifyOwnerSignature", "owner", owner, "err", err)
+		ReturnErr(c, serverErrRes)
+		return false
+	}
+	if !isValid {
+		ReturnErr(c, badRequestRes)
+		return false
+	}
+	return true
+}
+
 func (ms *MonitorSSV) getOwnerClusterInfo(owner string) (uint64, uint64, error) {
 	clusters, err := ms.store.GetAllClusterByEoaOwner(owner)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/monitorssv/monitorssv/eth2"
 	"github.com/monitorssv/monitorssv/store"
 	"math/big"
+	"strings"
 	"time"
 )
 
@@ -52,23 +53,40 @@ func (ms *MonitorSSV) Stop() {
 }
 
 type Status struct {
-	ELLastMonitoringBlock          uint64 `json:"el_last_monitoring_block"`
-	CLLastProposalMonitoringEpoch  uint64 `json:"cl_last_proposal_monitoring_epoch"`
-	CLLastValidatorMonitoringEpoch uint64 `json:"cl_last_validator_monitoring_epoch"`
+	ELLastMonitoringBlock          uint64                 `json:"el_last_monitoring_block"`
+	CLLastProposalMonitoringEpoch  uint64                 `json:"cl_last_proposal_monitoring_epoch"`
+	CLLastValidatorMonitoringEpoch uint64                 `json:"cl_last_validator_monitoring_epoch"`
+	ELEndpoints                    []utils.EndpointHealth `json:"el_endpoints"`
+	CLEndpoints                    []utils.EndpointHealth `json:"cl_endpoints"`
 }
 
 func (ms *MonitorSSV) Status(c *gin.Context) {
 	var status Status
 	status.ELLastMonitoringBlock = ms.ssv.GetLastProcessedBlock()
-	status.CLLastProposalMonitoringEpoch = ms.beaconMonitor.GetLastProcessedSlot()/32 - 1
+	status.CLLastProposalMonitoringEpoch = ms.beaconMonitor.GetProfile().SlotToEpoch(ms.beaconMonitor.GetLastProcessedSlot()) - 1
 	status.CLLastValidatorMonitoringEpoch = ms.beaconMonitor.GetLastValidatorMonitorEpoch()
+	status.ELEndpoints = ms.ssv.GetEndpointHealth()
+	status.CLEndpoints = ms.beaconMonitor.GetEndpointHealth()
 
 	ReturnOk(c, status)
 }
 
 type NetworkFee struct {
-	CurrentFee  string `json:"current"`
-	UpcomingFee string `json:"upcoming"`
+	CurrentFee  string               `json:"current"`
+	UpcomingFee string               `json:"upcoming"`
+	Timeline    []NetworkParamChange `json:"timeline"`
+}
+
+// NetworkParamChange values are in the unit of the param, see store.NetworkParam, and formatted for display
+type NetworkParamChange struct {
+	Param          string `json:"param"`
+	Block          uint64 `json:"block"`
+	Time           int64  `json:"time"`
+	OldValue       string `json:"oldValue"`
+	NewValue       string `json:"newValue"`
+	OldValueFormat string `json:"oldValueFormat"`
+	NewValueFormat string `json:"newValueFormat"`
+	TxHash         string `json:"txHash"`
 }
 
 func (ms *MonitorSSV) GetNetworkFees(c *gin.Context) {
@@ -93,9 +111,40 @@ func (ms *MonitorSSV) GetNetworkFees(c *gin.Context) {
 		upcomingNetworkFee = utils.ToSSV(fee, "%.2f")
 	}
 
+	// the timeline has every DAO parameter unless filtered with param=networkFee,liquidationThresholdPeriod
+	var params []string
+	if param := c.DefaultQuery("param", ""); param != "" {
+		params = strings.Split(param, ",")
+	}
+	paramInfos, err := ms.store.GetNetworkParamHistory(params)
+	if err != nil {
+		monitorLog.Errorw("GetNetworkFees: store.GetNetworkParamHistory", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
+	var timeline = make([]NetworkParamChange, 0, len(paramInfos))
+	for _, info := range paramInfos {
+		oldValueFormat := "unknown"
+		if info.OldValue != "" {
+			oldValueFormat = store.FormatNetworkParam(info.Param, info.OldValue)
+		}
+		timeline = append(timeline, NetworkParamChange{
+			Param:          info.Param,
+			Block:          info.BlockNumber,
+			Time:           info.BlockTime,
+			OldValue:       info.OldValue,
+			NewValue:       info.NewValue,
+			OldValueFormat: oldValueFormat,
+			NewValueFormat: store.FormatNetworkParam(info.Param, info.NewValue),
+			TxHash:         info.TxHash,
+		})
+	}
+
 	networkFee := NetworkFee{
 		CurrentFee:  chainNetworkInfo.NetworkFee,
 		UpcomingFee: upcomingNetworkFee,
+		Timeline:    timeline,
 	}
 
 	ReturnOk(c, networkFee)
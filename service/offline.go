@@ -0,0 +1,64 @@
+package service
+
+import (
+	"github.com/gin-gonic/gin"
+	"math"
+	"strconv"
+)
+
+type ValidatorOffline struct {
+	Index      uint64 `json:"index"`
+	StartEpoch uint64 `json:"startEpoch"`
+	// EndEpoch is 0 while the validator is still offline
+	EndEpoch     uint64 `json:"endEpoch"`
+	MissedDuties uint64 `json:"missedDuties"`
+}
+
+func (ms *MonitorSSV) GetValidatorOfflines(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		monitorLog.Warnw("GetValidatorOfflines", "page", page)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		monitorLog.Warnw("GetValidatorOfflines", "limit", limit)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	clusterId := c.DefaultQuery("clusterId", "")
+
+	if len(clusterId) != clusterIdLength {
+		monitorLog.Warnw("GetValidatorOfflines", "clusterId", clusterId)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	monitorLog.Infow("GetValidatorOfflines", "page", page, "limit", limit, "clusterId", clusterId)
+
+	offlineInfos, totalCount, err := ms.store.GetValidatorOfflinesByClusterId(page, limit, clusterId)
+	if err != nil {
+		monitorLog.Errorw("GetValidatorOfflines: GetValidatorOfflinesByClusterId", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
+	var offlines = make([]ValidatorOffline, 0)
+	for _, offlineInfo := range offlineInfos {
+		offlines = append(offlines, ValidatorOffline{
+			Index:        offlineInfo.ValidatorIndex,
+			StartEpoch:   offlineInfo.StartEpoch,
+			EndEpoch:     offlineInfo.EndEpoch,
+			MissedDuties: offlineInfo.MissedDuties,
+		})
+	}
+
+	ReturnOk(c, gin.H{
+		"offlines":    offlines,
+		"totalItems":  totalCount,
+		"totalPages":  int(math.Ceil(float64(totalCount) / float64(limit))),
+		"currentPage": page,
+	})
+}
@@ -4,12 +4,20 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/monitorssv/monitorssv/eth1/ssv"
 	"github.com/monitorssv/monitorssv/eth1/utils"
 	"github.com/monitorssv/monitorssv/store"
 	"math"
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// an operator raising its fee feeIncreaseFrequentCount times within feeIncreaseWindow is flagged
+	feeIncreaseWindow        = 180 * 24 * time.Hour
+	feeIncreaseFrequentCount = 3
 )
 
 type OperatorIntro struct {
@@ -30,6 +38,23 @@ type Operator struct {
 	PendingOperatorFee string   `json:"pendingOperatorFee"`
 	BeginUpdateTime    int64    `json:"beginUpdateTime"`
 	EndUpdateTime      int64    `json:"endUpdateTime"`
+	Performance24h     float64  `json:"performance24h"`
+	Performance7d      float64  `json:"performance7d"`
+	Performance30d     float64  `json:"performance30d"`
+	Incidents30d       uint64   `json:"incidents30d"`
+	FeeIncreases       int64    `json:"feeIncreases"`
+	FrequentFeeRaiser  bool     `json:"frequentFeeRaiser"`
+}
+
+type OperatorFee struct {
+	Block        uint64 `json:"block"`
+	Time         int64  `json:"time"`
+	Action       string `json:"action"`
+	OldFee       string `json:"oldFee"`
+	NewFee       string `json:"newFee"`
+	OldAnnualFee string `json:"oldAnnualFee"`
+	NewAnnualFee string `json:"newAnnualFee"`
+	TxHash       string `json:"txHash"`
 }
 
 func (ms *MonitorSSV) getOperatorIntro(id uint64) OperatorIntro {
@@ -64,17 +89,43 @@ func (ms *MonitorSSV) GetOperators(c *gin.Context) {
 		return
 	}
 
+	// sort by performance score: 24h, 7d or 30d
+	sortBy := c.DefaultQuery("sort", "")
+	if sortBy != "" && sortBy != "24h" && sortBy != "7d" && sortBy != "30d" {
+		monitorLog.Warnw("GetOperators", "sort", sortBy)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	minScore, err := strconv.ParseFloat(c.DefaultQuery("minScore", "0"), 64)
+	if err != nil {
+		monitorLog.Warnw("GetOperators", "minScore", c.Query("minScore"))
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
 	search := c.DefaultQuery("search", "")
-	monitorLog.Infow("GetOperators", "page", page, "limit", limit, "search", search)
+	monitorLog.Infow("GetOperators", "page", page, "limit", limit, "search", search, "sort", sortBy, "minScore", minScore)
 
 	var operatorInfos []store.OperatorInfo
 	var totalCount int64
 	if search == "" {
-		operatorInfos, totalCount, err = ms.store.GetOperators(page, limit)
-		if err != nil {
-			monitorLog.Errorw("GetOperators: GetOperators", "err", err.Error())
-			ReturnErr(c, serverErrRes)
-			return
+		if sortBy != "" || minScore > 0 {
+			if sortBy == "" {
+				sortBy = "30d"
+			}
+			operatorInfos, totalCount, err = ms.store.GetOperatorsByPerformance(page, limit, sortBy, minScore)
+			if err != nil {
+				monitorLog.Errorw("GetOperators: GetOperatorsByPerformance", "err", err.Error())
+				ReturnErr(c, serverErrRes)
+				return
+			}
+		} else {
+			operatorInfos, totalCount, err = ms.store.GetOperators(page, limit)
+			if err != nil {
+				monitorLog.Errorw("GetOperators: GetOperators", "err", err.Error())
+				ReturnErr(c, serverErrRes)
+				return
+			}
 		}
 	}
 
@@ -117,6 +168,13 @@ func (ms *MonitorSSV) GetOperators(c *gin.Context) {
 		}
 	}
 
+	feeIncreases, err := ms.getOperatorFeeIncreases(operatorInfos)
+	if err != nil {
+		monitorLog.Errorw("GetOperators: getOperatorFeeIncreases", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
 	var operators = make([]Operator, 0)
 	for _, info := range operatorInfos {
 		operatorFee := info.OperatorFee
@@ -166,6 +224,12 @@ func (ms *MonitorSSV) GetOperators(c *gin.Context) {
 			PendingOperatorFee: pendingOperatorFee,
 			BeginUpdateTime:    info.ApprovalBeginTime,
 			EndUpdateTime:      info.ApprovalEndTime,
+			Performance24h:     info.Performance24h,
+			Performance7d:      info.Performance7d,
+			Performance30d:     info.Performance30d,
+			Incidents30d:       info.Incidents30d,
+			FeeIncreases:       feeIncreases[info.OperatorId],
+			FrequentFeeRaiser:  feeIncreases[info.OperatorId] >= feeIncreaseFrequentCount,
 		})
 	}
 
@@ -177,3 +241,82 @@ func (ms *MonitorSSV) GetOperators(c *gin.Context) {
 	})
 	return
 }
+
+func (ms *MonitorSSV) getOperatorFeeIncreases(operatorInfos []store.OperatorInfo) (map[uint64]int64, error) {
+	if len(operatorInfos) == 0 {
+		return map[uint64]int64{}, nil
+	}
+	operatorIds := make([]uint64, 0, len(operatorInfos))
+	for _, info := range operatorInfos {
+		operatorIds = append(operatorIds, info.OperatorId)
+	}
+	since := time.Now().Add(-feeIncreaseWindow).Unix()
+	return ms.store.GetOperatorFeeIncreaseCounts(operatorIds, ssv.OperatorFeeExecuted, since)
+}
+
+func (ms *MonitorSSV) GetOperatorFeeHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Query("id"), 10, 64)
+	if err != nil {
+		monitorLog.Warnw("GetOperatorFeeHistory", "id", c.Query("id"))
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	monitorLog.Infow("GetOperatorFeeHistory", "id", id)
+
+	operatorInfo, err := ms.store.GetOperatorByOperatorId(id)
+	if err != nil {
+		monitorLog.Errorw("GetOperatorFeeHistory: GetOperatorByOperatorId", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	if operatorInfo == nil {
+		ReturnErr(c, notFoundRes)
+		return
+	}
+
+	feeInfos, err := ms.store.GetOperatorFeeHistory(id)
+	if err != nil {
+		monitorLog.Errorw("GetOperatorFeeHistory: GetOperatorFeeHistory", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
+	since := time.Now().Add(-feeIncreaseWindow).Unix()
+	var feeIncreases int64
+	var fees = make([]OperatorFee, 0, len(feeInfos))
+	for _, info := range feeInfos {
+		if info.Action == ssv.OperatorFeeExecuted && info.Increase && info.BlockTime >= since {
+			feeIncreases++
+		}
+		fees = append(fees, OperatorFee{
+			Block:        info.BlockNumber,
+			Time:         info.BlockTime,
+			Action:       info.Action,
+			OldFee:       info.OldFee,
+			NewFee:       info.NewFee,
+			OldAnnualFee: annualOperatorFee(info.OldFee),
+			NewAnnualFee: annualOperatorFee(info.NewFee),
+			TxHash:       info.TxHash,
+		})
+	}
+
+	ReturnOk(c, gin.H{
+		"id":                id,
+		"operatorFee":       annualOperatorFee(operatorInfo.OperatorFee),
+		"fees":              fees,
+		"feeIncreases":      feeIncreases,
+		"frequentFeeRaiser": feeIncreases >= feeIncreaseFrequentCount,
+	})
+}
+
+// annualOperatorFee converts a per block fee to SSV per year
+func annualOperatorFee(operatorFee string) string {
+	fee, isOk := big.NewInt(0).SetString(operatorFee, 10)
+	if !isOk {
+		monitorLog.Warnw("annualOperatorFee: Failed to parse operatorFee", "operatorFee", operatorFee)
+		return operatorFee
+	}
+	fee = big.NewInt(0).Mul(fee, big.NewInt(2613400))
+	return utils.ToSSV(fee, "%.2f")
+}
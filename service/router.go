@@ -12,14 +12,19 @@ func (ms *MonitorSSV) NewRouter() *gin.Engine {
 	r.GET("/api/status", ms.Status)
 	r.GET("/api/dashboard", ms.Dashboard)
 	r.GET("/api/operators", ms.GetOperators)
+	r.GET("/api/operatorFeeHistory", ms.GetOperatorFeeHistory)
 	r.GET("/api/clusters", ms.GetClusters)
 	r.GET("/api/clusterDetails", ms.GetClusterDetails)
+	r.GET("/api/clusterLedger", ms.GetClusterLedger)
+	r.GET("/api/simulateCluster", ms.SimulateCluster)
 	r.GET("/api/getNetworkFees", ms.GetNetworkFees)
 	r.GET("/api/get30DayLiquidationRankingClusters", ms.Get30DayLiquidationRankingClusters)
 	r.GET("/api/get30DaySimulatedLiquidationRankingClusters", ms.Get30DaySimulatedLiquidationRankingClusters)
 	r.GET("/api/validators", ms.GetValidators)
+	r.GET("/api/validatorDetails", ms.GetValidatorDetails)
 	r.GET("/api/events", ms.GetEvents)
 	r.GET("/api/blocks", ms.GetBlocks)
+	r.GET("/api/offlines", ms.GetValidatorOfflines)
 	r.GET("/api/posData", ms.GetPosData)
 	r.GET("/api/claim", ms.GetSSVReward)
 
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/gin-gonic/gin"
+	"github.com/monitorssv/monitorssv/eth1/ssv"
+	"github.com/monitorssv/monitorssv/eth1/utils"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ssvTotalSupply caps the hypothetical deposit, no cluster can hold more SSV than exists
+var ssvTotalSupply = new(big.Int).Mul(big.NewInt(11_000_000), big.NewInt(params.Ether))
+
+type ClusterSimulation struct {
+	ID             string            `json:"id"`
+	OperatorIds    []uint64          `json:"operatorIds"`
+	ValidatorCount uint32            `json:"validatorCount"`
+	Deposit        string            `json:"deposit"`
+	TargetDays     uint64            `json:"targetDays"`
+	MinimumDeposit string            `json:"minimumDeposit"`
+	Projection     *RunwayProjection `json:"projection"`
+}
+
+// SimulateCluster projects the runway of a cluster after a hypothetical deposit in SSV, a change of its validator
+// count and operator swaps given as old:new, e.g. ?deposit=50&validators=4&swap=12:87&targetDays=180
+func (ms *MonitorSSV) SimulateCluster(c *gin.Context) {
+	clusterId := c.DefaultQuery("clusterId", "")
+	if len(clusterId) != clusterIdLength {
+		monitorLog.Warnw("SimulateCluster", "clusterId", clusterId)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	deposit, ok := parseSSV(c.DefaultQuery("deposit", "0"))
+	if !ok {
+		monitorLog.Warnw("SimulateCluster", "deposit", c.Query("deposit"))
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	validatorDelta, err := strconv.ParseInt(c.DefaultQuery("validators", "0"), 10, 64)
+	if err != nil {
+		monitorLog.Warnw("SimulateCluster", "validators", c.Query("validators"))
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	targetDays, err := strconv.ParseUint(c.DefaultQuery("targetDays", "0"), 10, 64)
+	if err != nil || targetDays > 36500 {
+		monitorLog.Warnw("SimulateCluster", "targetDays", c.Query("targetDays"))
+		ReturnErr(c, badRequestRes)
+		return
+	}
+	swaps, err := parseOperatorSwaps(c.QueryArray("swap"))
+	if err != nil {
+		monitorLog.Warnw("SimulateCluster", "swap", c.QueryArray("swap"), "err", err.Error())
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	monitorLog.Infow("SimulateCluster", "clusterId", clusterId, "deposit", deposit.String(), "validators", validatorDelta, "swaps", swaps, "targetDays", targetDays)
+
+	clusterInfo, err := ms.store.GetClusterByClusterId(clusterId)
+	if err != nil {
+		monitorLog.Errorw("SimulateCluster: GetClusterByClusterId", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	if clusterInfo == nil || !clusterInfo.Active {
+		ReturnErr(c, notFoundRes)
+		return
+	}
+
+	result, err := ms.ssv.SimulateClusterRunway(*clusterInfo, ssv.ClusterSimulation{
+		Deposit:        deposit,
+		ValidatorDelta: validatorDelta,
+		Swaps:          swaps,
+		TargetRunway:   targetDays * 7200,
+	})
+	if err != nil {
+		if errors.Is(err, ssv.ErrInvalidSimulation) {
+			monitorLog.Warnw("SimulateCluster: SimulateClusterRunway", "clusterId", clusterId, "err", err.Error())
+			ReturnErr(c, badRequestRes)
+			return
+		}
+		monitorLog.Errorw("SimulateCluster: SimulateClusterRunway", "clusterId", clusterId, "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+
+	simulation := ClusterSimulation{
+		ID:             clusterId,
+		OperatorIds:    result.OperatorIds,
+		ValidatorCount: result.ValidatorCount,
+		Deposit:        utils.ToSSV(deposit, "%.9f"),
+		TargetDays:     targetDays,
+		Projection:     newRunwayProjection(result.Projection),
+	}
+	if result.MinimumDeposit != nil {
+		simulation.MinimumDeposit = utils.ToSSV(result.MinimumDeposit, "%.9f")
+	}
+
+	ReturnOk(c, gin.H{
+		"simulation": simulation,
+	})
+	return
+}
+
+// parseSSV parses a non negative decimal SSV amount up to the total supply into wei
+func parseSSV(amount string) (*big.Int, bool) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok || value.Sign() < 0 {
+		return nil, false
+	}
+	value.Mul(value, new(big.Rat).SetInt64(params.Ether))
+	res := new(big.Int).Quo(value.Num(), value.Denom())
+	if res.Cmp(ssvTotalSupply) > 0 {
+		return nil, false
+	}
+	return res, true
+}
+
+func parseOperatorSwaps(swaps []string) (map[uint64]uint64, error) {
+	res := make(map[uint64]uint64)
+	for _, swap := range swaps {
+		for _, pair := range strings.Split(swap, ",") {
+			ids := strings.Split(pair, ":")
+			if len(ids) != 2 {
+				return nil, errors.New("swap must be old:new")
+			}
+			oldId, err := strconv.ParseUint(ids[0], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			newId, err := strconv.ParseUint(ids[1], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := res[oldId]; ok {
+				return nil, errors.New("operator swapped twice")
+			}
+			res[oldId] = newId
+		}
+	}
+	return res, nil
+}
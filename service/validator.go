@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
+	"github.com/monitorssv/monitorssv/eth2"
 	"github.com/monitorssv/monitorssv/store"
 	"math"
 	"strconv"
@@ -16,6 +17,10 @@ type Validator struct {
 	ClusterId string          `json:"clusterId"`
 	Status    string          `json:"status"`
 	Online    bool            `json:"online"`
+	// Activation is set while the validator waits in the activation queue
+	Activation *eth2.ActivationEstimate `json:"activation,omitempty"`
+	// Withdrawal is set once the validator initiated an exit
+	Withdrawal *eth2.WithdrawalTimeline `json:"withdrawal,omitempty"`
 }
 
 func (ms *MonitorSSV) GetValidators(c *gin.Context) {
@@ -101,6 +106,9 @@ func (ms *MonitorSSV) GetValidators(c *gin.Context) {
 			ClusterId: info.ClusterID,
 			Status:    info.Status,
 			Online:    info.IsOnline,
+
+			Activation: ms.beaconMonitor.ActivationEstimate(&info),
+			Withdrawal: ms.beaconMonitor.WithdrawalTimeline(&info),
 		})
 	}
 
@@ -112,3 +120,177 @@ func (ms *MonitorSSV) GetValidators(c *gin.Context) {
 	})
 	return
 }
+
+type ValidatorRegistration struct {
+	ClusterId         string          `json:"clusterId"`
+	Owner             string          `json:"owner"`
+	Operators         []OperatorIntro `json:"operators"`
+	RegistrationBlock int64           `json:"registrationBlock"`
+	RemoveBlock       int64           `json:"removeBlock"`
+}
+
+type ValidatorStatusChange struct {
+	Epoch  uint64 `json:"epoch"`
+	Status string `json:"status"`
+}
+
+type ValidatorDetails struct {
+	Validator
+	Index int64 `json:"index"`
+	// Beacon is the current beacon state, nil if the beacon node could not be reached
+	Beacon         *eth2.ValidatorState    `json:"beacon"`
+	StatusHistory  []ValidatorStatusChange `json:"statusHistory"`
+	TotalBlocks    int64                   `json:"totalBlocks"`
+	LatestBlocks   []Block                 `json:"latestBlocks"`
+	OfflinePeriods []ValidatorOffline      `json:"offlinePeriods"`
+	// Registrations are all the registrations of the public key in SSV, oldest first
+	Registrations []ValidatorRegistration `json:"registrations"`
+}
+
+func (ms *MonitorSSV) GetValidatorDetails(c *gin.Context) {
+	pubKey := strings.TrimPrefix(c.DefaultQuery("pubKey", ""), "0x")
+	index := c.DefaultQuery("index", "")
+
+	if pubKey == "" && index != "" {
+		validatorIndex, err := strconv.ParseInt(index, 10, 64)
+		if err != nil || validatorIndex < 0 {
+			monitorLog.Warnw("GetValidatorDetails", "index", index)
+			ReturnErr(c, badRequestRes)
+			return
+		}
+
+		// removed and exited validators are looked up too, their history is kept
+		validatorInfo, err := ms.store.GetValidatorByValidatorIndex(validatorIndex)
+		if err != nil {
+			monitorLog.Errorw("GetValidatorDetails: GetValidatorByValidatorIndex", "err", err.Error())
+			ReturnErr(c, serverErrRes)
+			return
+		}
+		if validatorInfo == nil {
+			ReturnErr(c, badRequestRes)
+			return
+		}
+		pubKey = validatorInfo.PublicKey
+	}
+
+	if len(pubKey) != pubKeyLength {
+		monitorLog.Warnw("GetValidatorDetails", "pubKey", pubKey)
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	monitorLog.Infow("GetValidatorDetails", "pubKey", pubKey, "index", index)
+
+	registrations, err := ms.store.GetValidatorRegistrations(pubKey)
+	if err != nil {
+		monitorLog.Errorw("GetValidatorDetails: GetValidatorRegistrations", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	if len(registrations) == 0 {
+		ReturnErr(c, badRequestRes)
+		return
+	}
+
+	// the latest registration is the current one
+	info := registrations[len(registrations)-1]
+	var details ValidatorDetails
+	details.Validator = Validator{
+		PublicKey: info.PublicKey,
+		Owner:     info.Owner,
+		Operators: ms.getOperatorIntros(info.OperatorIds),
+		ClusterId: info.ClusterID,
+		Status:    info.Status,
+		Online:    info.IsOnline,
+
+		Activation: ms.beaconMonitor.ActivationEstimate(&info),
+		Withdrawal: ms.beaconMonitor.WithdrawalTimeline(&info),
+	}
+	details.Index = info.ValidatorIndex
+
+	for _, registration := range registrations {
+		details.Registrations = append(details.Registrations, ValidatorRegistration{
+			ClusterId:         registration.ClusterID,
+			Owner:             registration.Owner,
+			Operators:         ms.getOperatorIntros(registration.OperatorIds),
+			RegistrationBlock: registration.RegistrationBlock,
+			RemoveBlock:       registration.RemoveBlock,
+		})
+	}
+
+	if ms.beaconMonitor.Enabled() {
+		details.Beacon, err = ms.beaconMonitor.GetValidatorState(pubKey)
+		if err != nil {
+			monitorLog.Warnw("GetValidatorDetails: GetValidatorState", "err", err.Error())
+		}
+	}
+
+	statusHistory, err := ms.store.GetValidatorStatusHistory(pubKey)
+	if err != nil {
+		monitorLog.Errorw("GetValidatorDetails: GetValidatorStatusHistory", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	details.StatusHistory = make([]ValidatorStatusChange, 0)
+	for _, status := range statusHistory {
+		details.StatusHistory = append(details.StatusHistory, ValidatorStatusChange{
+			Epoch:  status.Epoch,
+			Status: status.Status,
+		})
+	}
+
+	details.TotalBlocks, err = ms.store.GetValidatorTotalBlockCount(pubKey)
+	if err != nil {
+		monitorLog.Errorw("GetValidatorDetails: GetValidatorTotalBlockCount", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	blockInfos, err := ms.store.GetLatestBlocksByPublicKey(pubKey, 10)
+	if err != nil {
+		monitorLog.Errorw("GetValidatorDetails: GetLatestBlocksByPublicKey", "err", err.Error())
+		ReturnErr(c, serverErrRes)
+		return
+	}
+	details.LatestBlocks = make([]Block, 0)
+	for _, blockInfo := range blockInfos {
+		details.LatestBlocks = append(details.LatestBlocks, Block{
+			Proposer:     blockInfo.Proposer,
+			Epoch:        blockInfo.Epoch,
+			Slot:         blockInfo.Slot,
+			BlockNumber:  blockInfo.BlockNumber,
+			IsMissed:     blockInfo.IsMissed,
+			MissedReason: blockInfo.MissedReason,
+		})
+	}
+
+	details.OfflinePeriods = make([]ValidatorOffline, 0)
+	if info.ValidatorIndex != store.DefaultValidatorIndex {
+		offlineInfos, err := ms.store.GetValidatorOfflinesByIndex(uint64(info.ValidatorIndex))
+		if err != nil {
+			monitorLog.Errorw("GetValidatorDetails: GetValidatorOfflinesByIndex", "err", err.Error())
+			ReturnErr(c, serverErrRes)
+			return
+		}
+		for _, offlineInfo := range offlineInfos {
+			details.OfflinePeriods = append(details.OfflinePeriods, ValidatorOffline{
+				Index:        offlineInfo.ValidatorIndex,
+				StartEpoch:   offlineInfo.StartEpoch,
+				EndEpoch:     offlineInfo.EndEpoch,
+				MissedDuties: offlineInfo.MissedDuties,
+			})
+		}
+	}
+
+	ReturnOk(c, gin.H{
+		"validatorDetails": details,
+	})
+}
+
+func (ms *MonitorSSV) getOperatorIntros(operatorIds string) []OperatorIntro {
+	var operators = make([]OperatorIntro, 0)
+	for _, operatorId := range strings.Split(operatorIds, ",") {
+		id, _ := strconv.Atoi(operatorId)
+		operators = append(operators, ms.getOperatorIntro(uint64(id)))
+	}
+	return operators
+}
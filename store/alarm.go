@@ -17,8 +17,10 @@ type AlarmInfo struct {
 	ReportProposeBlock         bool   `json:"report_propose_block"`
 	ReportMissedBlock          bool   `json:"report_missed_block"`
 	ReportBalanceDecrease      bool   `json:"report_balance_decrease"`
-	ReportExitedButNotRemoved  bool   `json:"report_exited_but_not_removed"`
-	ReportWeekly               bool   `json:"report_weekly"`
+	// ReportOfflineEpochs alerts validators offline for this many epochs, 0 disables it
+	ReportOfflineEpochs       uint64 `json:"report_offline_epochs"`
+	ReportExitedButNotRemoved bool   `json:"report_exited_but_not_removed"`
+	ReportWeekly              bool   `json:"report_weekly"`
 }
 
 func (s *AlarmInfo) TableName() string {
@@ -72,6 +74,7 @@ func (s *Store) CreateOrUpdateAlarmInfo(info *AlarmInfo) error {
 	alarmInfo.ReportProposeBlock = info.ReportProposeBlock
 	alarmInfo.ReportMissedBlock = info.ReportMissedBlock
 	alarmInfo.ReportBalanceDecrease = info.ReportBalanceDecrease
+	alarmInfo.ReportOfflineEpochs = info.ReportOfflineEpochs
 	alarmInfo.ReportExitedButNotRemoved = info.ReportExitedButNotRemoved
 	alarmInfo.ReportWeekly = info.ReportWeekly
 	return s.db.Save(alarmInfo).Error
@@ -6,6 +6,16 @@ import (
 	"strings"
 )
 
+// MissedReason of a missed proposal
+const (
+	// MissedNoBlock no block was produced for the slot, the operators failed to propose
+	MissedNoBlock = "no_block"
+	// MissedOrphaned a block was produced but reorged out, usually it was published too late
+	MissedOrphaned = "orphaned"
+	// MissedUnknown the event stream was not live for the slot, so an orphaned block may have gone unseen
+	MissedUnknown = "unknown"
+)
+
 type BlockInfo struct {
 	gorm.Model
 	ClusterID   string `gorm:"type:VARCHAR(64); index" json:"cluster_id"`
@@ -15,6 +25,12 @@ type BlockInfo struct {
 	Proposer    uint64 `json:"proposer"`
 	PublicKey   string `json:"public_key"`
 	IsMissed    bool   `gorm:"index" json:"is_missed"`
+	// MissedReason is empty unless IsMissed is set
+	MissedReason string `gorm:"type:VARCHAR(16)" json:"missed_reason"`
+	// FeeRecipient is the fee recipient of the execution payload, BlockValue the execution rewards in wei
+	FeeRecipient         string `json:"fee_recipient"`
+	BlockValue           string `gorm:"default:0" json:"block_value"`
+	FeeRecipientMismatch bool   `gorm:"default:false;index" json:"fee_recipient_mismatch"`
 }
 
 func (s *BlockInfo) TableName() string {
@@ -67,6 +83,25 @@ func (s *Store) GetBlockInfoByClusterId(clusterId string) (int64, int64, error)
 	return totalCount, totalMissedCount, nil
 }
 
+type ClusterBlockStats struct {
+	ClusterID string
+	Proposed  uint64
+	Missed    uint64
+}
+
+func (s *Store) GetClusterBlockStats(fromEpoch uint64) ([]ClusterBlockStats, error) {
+	var stats []ClusterBlockStats
+	err := s.db.Model(&BlockInfo{}).
+		Select("cluster_id, SUM(is_missed = 0) as proposed, SUM(is_missed = 1) as missed").
+		Where("epoch >= ?", fromEpoch).
+		Group("cluster_id").
+		Find(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
 func (s *Store) GetValidatorTotalBlockCount(pubKey string) (int64, error) {
 	var totalCount int64
 	err := s.db.Model(&BlockInfo{}).Where(&BlockInfo{PublicKey: pubKey}).Count(&totalCount).Error
@@ -76,6 +111,15 @@ func (s *Store) GetValidatorTotalBlockCount(pubKey string) (int64, error) {
 	return totalCount, nil
 }
 
+func (s *Store) GetLatestBlocksByPublicKey(pubKey string, limit int) ([]BlockInfo, error) {
+	var blocks []BlockInfo
+	err := s.db.Model(&BlockInfo{}).Where(&BlockInfo{PublicKey: pubKey}).Order("slot DESC").Limit(limit).Find(&blocks).Error
+	if err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
 func (s *Store) GetBlockByClusterId(page int, itemsPerPage int, clusterID string) ([]BlockInfo, int64, error) {
 	perPage, offset := pagingCheck(page, itemsPerPage)
 	var totalCount int64
@@ -95,6 +139,25 @@ func (s *Store) GetBlockByClusterId(page int, itemsPerPage int, clusterID string
 	return blocks, totalCount, nil
 }
 
+func (s *Store) IsBlockRecorded(slot uint64) (bool, error) {
+	var count int64
+	err := s.db.Model(&BlockInfo{}).Where(&BlockInfo{Slot: slot}).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetBlockSlots returns the recorded proposal slots in [fromSlot, toSlot]
+func (s *Store) GetBlockSlots(fromSlot, toSlot uint64) ([]uint64, error) {
+	var slots []uint64
+	err := s.db.Model(&BlockInfo{}).Where("slot >= ? AND slot <= ?", fromSlot, toSlot).Order("slot").Pluck("slot", &slots).Error
+	if err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
 func (s *Store) GetAllBlocks() ([]BlockInfo, error) {
 	var blocks []BlockInfo
 	err := s.db.Model(&BlockInfo{}).Find(&blocks).Error
@@ -26,6 +26,9 @@ type ClusterInfo struct {
 	UpcomingBurnFee          uint64 `gorm:"default:0" json:"upcoming_burn_fee"`
 	UpcomingLiquidationBlock uint64 `gorm:"default:0" json:"upcoming_liquidation_block"`
 	UpcomingCalcTime         int64  `gorm:"default:0" json:"upcoming_calc_time"`
+	// TopUpAmount in wei restores a runway of TopUpTargetDays, calculated with the upcoming liquidation block
+	TopUpAmount     string `gorm:"default:0" json:"top_up_amount"`
+	TopUpTargetDays uint64 `gorm:"default:0" json:"top_up_target_days"`
 }
 
 func CalcClusterOnChainBalance(curBlock uint64, clusterInfo *ClusterInfo) string {
@@ -201,6 +204,17 @@ func (s *Store) GetNoUpdatedClustersOwner() ([]string, error) {
 	return owners, nil
 }
 
+// GetCreatorResolvedClustersOwner returns the contract owners whose eoa_owner was resolved to their creator
+func (s *Store) GetCreatorResolvedClustersOwner() ([]string, error) {
+	var owners []string
+	err := s.db.Model(&ClusterInfo{}).Where("eoa_owner != '0x' AND eoa_owner != owner").Distinct().Pluck("owner", &owners).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
 func (s *Store) CreateOrUpdateCluster(info *ClusterInfo) error {
 	var cluster ClusterInfo
 	err := s.db.Where(&ClusterInfo{ClusterID: info.ClusterID}).First(&cluster).Error
@@ -230,8 +244,8 @@ func (s *Store) UpdateClusterLiquidationInfo(clusterID string, liquidationBlock
 	return s.db.Model(&ClusterInfo{}).Where(&ClusterInfo{ClusterID: clusterID}).Updates(map[string]interface{}{"liquidation_block": liquidationBlock, "burn_fee": burnFee, "calc_liquidation_block": calculateLiquidationBlock, "on_chain_balance": onChainBalance}).Error
 }
 
-func (s *Store) UpdateUpcomingClusterLiquidationInfo(clusterID string, upcomingLiquidationBlock uint64, upcomingCalcTime int64, upcomingBurnFee uint64) error {
-	return s.db.Model(&ClusterInfo{}).Where(&ClusterInfo{ClusterID: clusterID}).Updates(map[string]interface{}{"upcoming_liquidation_block": upcomingLiquidationBlock, "upcoming_burn_fee": upcomingBurnFee, "upcoming_calc_time": upcomingCalcTime}).Error
+func (s *Store) UpdateUpcomingClusterLiquidationInfo(clusterID string, upcomingLiquidationBlock uint64, upcomingCalcTime int64, upcomingBurnFee uint64, topUpAmount string, topUpTargetDays uint64) error {
+	return s.db.Model(&ClusterInfo{}).Where(&ClusterInfo{ClusterID: clusterID}).Updates(map[string]interface{}{"upcoming_liquidation_block": upcomingLiquidationBlock, "upcoming_burn_fee": upcomingBurnFee, "upcoming_calc_time": upcomingCalcTime, "top_up_amount": topUpAmount, "top_up_target_days": topUpTargetDays}).Error
 }
 
 func (s *Store) UpdateClusterStatus(clusterID string, status string) error {
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ClusterLedgerInfo is a cluster event that changed the cluster balance, amounts are in SSV wei.
+// Amount is what the owner paid in (negative when taken out, by a withdrawal or a liquidation),
+// Burn is the fee burnt since the previous ledger entry and Balance the cluster balance after the event.
+type ClusterLedgerInfo struct {
+	gorm.Model
+	ClusterID      string `gorm:"type:VARCHAR(64); index" json:"cluster_id"`
+	Action         string `json:"action"`
+	BlockNumber    uint64 `gorm:"index" json:"block_number"`
+	BlockTime      int64  `json:"block_time"`
+	Amount         string `json:"amount"`
+	Burn           string `json:"burn"`
+	Balance        string `json:"balance"`
+	ValidatorCount uint32 `json:"validator_count"`
+	TxHash         string `gorm:"type:VARCHAR(70); uniqueIndex:ledger_txhash_logindex" json:"tx_hash"`
+	LogIndex       uint   `gorm:"uniqueIndex:ledger_txhash_logindex" json:"log_index"`
+}
+
+func (s *ClusterLedgerInfo) TableName() string {
+	return "cluster_ledger_infos"
+}
+
+func (s *Store) CreateClusterLedger(info *ClusterLedgerInfo) error {
+	err := s.db.Create(info).Error
+	if err == nil {
+		return nil
+	}
+
+	// maybe rescan event
+	if strings.Contains(err.Error(), "Duplicate entry") {
+		return nil
+	}
+	return err
+}
+
+func (s *Store) GetClusterLedger(clusterId string) ([]ClusterLedgerInfo, error) {
+	var infos []ClusterLedgerInfo
+	err := s.db.Model(&ClusterLedgerInfo{}).Where("cluster_id = ?", clusterId).Order("block_number ASC, log_index ASC").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
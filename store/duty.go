@@ -0,0 +1,95 @@
+package store
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DutyBucketEpochs is the number of epochs aggregated in one ClusterDutyInfo row, 75 epochs are 8 hours
+const DutyBucketEpochs = 75
+
+// ClusterDutyInfo counts the attestation duties of a cluster's validators
+type ClusterDutyInfo struct {
+	gorm.Model
+	ClusterID string `gorm:"type:VARCHAR(64); uniqueIndex:clusterid_bucket" json:"cluster_id"`
+	Bucket    uint64 `gorm:"uniqueIndex:clusterid_bucket; index" json:"bucket"`
+	Attested  uint64 `json:"attested"`
+	Missed    uint64 `json:"missed"`
+	// Outages is the number of epochs in which most validators of the cluster missed their attestation
+	Outages uint64 `json:"outages"`
+}
+
+func (s *ClusterDutyInfo) TableName() string {
+	return "cluster_duty_infos"
+}
+
+func (s *Store) AddClusterDuties(clusterId string, epoch, attested, missed, outages uint64) error {
+	return s.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"attested": gorm.Expr("attested + ?", attested),
+			"missed":   gorm.Expr("missed + ?", missed),
+			"outages":  gorm.Expr("outages + ?", outages),
+		}),
+	}).Create(&ClusterDutyInfo{
+		ClusterID: clusterId,
+		Bucket:    epoch / DutyBucketEpochs,
+		Attested:  attested,
+		Missed:    missed,
+		Outages:   outages,
+	}).Error
+}
+
+type ClusterDutyStats struct {
+	ClusterID string
+	Attested  uint64
+	Missed    uint64
+	Outages   uint64
+}
+
+// GetClusterDutyStats sums the duties from fromEpoch on. The bucket holding fromEpoch is only partly in the window,
+// its counts are scaled to the epochs it covers from fromEpoch on.
+func (s *Store) GetClusterDutyStats(fromEpoch uint64) ([]ClusterDutyStats, error) {
+	firstBucket := fromEpoch / DutyBucketEpochs
+	var stats []ClusterDutyStats
+	err := s.db.Model(&ClusterDutyInfo{}).
+		Select("cluster_id, SUM(attested) as attested, SUM(missed) as missed, SUM(outages) as outages").
+		Where("bucket > ?", firstBucket).
+		Group("cluster_id").
+		Find(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var partial []ClusterDutyStats
+	err = s.db.Model(&ClusterDutyInfo{}).
+		Select("cluster_id, attested, missed, outages").
+		Where("bucket = ?", firstBucket).
+		Find(&partial).Error
+	if err != nil {
+		return nil, err
+	}
+	return addPartialBucket(stats, partial, fromEpoch), nil
+}
+
+func addPartialBucket(stats, partial []ClusterDutyStats, fromEpoch uint64) []ClusterDutyStats {
+	covered := DutyBucketEpochs - fromEpoch%DutyBucketEpochs
+	scale := func(v uint64) uint64 {
+		return v * covered / DutyBucketEpochs
+	}
+
+	clusters := make(map[string]int, len(stats))
+	for i, stat := range stats {
+		clusters[stat.ClusterID] = i
+	}
+	for _, bucket := range partial {
+		i, ok := clusters[bucket.ClusterID]
+		if !ok {
+			i = len(stats)
+			stats = append(stats, ClusterDutyStats{ClusterID: bucket.ClusterID})
+		}
+		stats[i].Attested += scale(bucket.Attested)
+		stats[i].Missed += scale(bucket.Missed)
+		stats[i].Outages += scale(bucket.Outages)
+	}
+	return stats
+}
@@ -0,0 +1,29 @@
+package store
+
+import "testing"
+
+func TestAddPartialBucket(t *testing.T) {
+	stats := []ClusterDutyStats{{ClusterID: "a", Attested: 100, Missed: 2}}
+	partial := []ClusterDutyStats{
+		{ClusterID: "a", Attested: 150, Missed: 30, Outages: 3},
+		{ClusterID: "b", Attested: 75},
+	}
+
+	// fromEpoch is 50 epochs into its bucket, so 25 of its 75 epochs are in the window
+	res := addPartialBucket(stats, partial, 10*DutyBucketEpochs+50)
+	if len(res) != 2 {
+		t.Fatalf("got %d clusters", len(res))
+	}
+	if res[0] != (ClusterDutyStats{ClusterID: "a", Attested: 150, Missed: 12, Outages: 1}) {
+		t.Fatalf("cluster a: %+v", res[0])
+	}
+	if res[1] != (ClusterDutyStats{ClusterID: "b", Attested: 25}) {
+		t.Fatalf("cluster b: %+v", res[1])
+	}
+
+	// a window starting on a bucket boundary counts the whole bucket
+	res = addPartialBucket(nil, partial, 10*DutyBucketEpochs)
+	if res[0].Attested != 150 || res[1].Attested != 75 {
+		t.Fatalf("full bucket: %+v", res)
+	}
+}
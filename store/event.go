@@ -14,6 +14,9 @@ type EventInfo struct {
 	LogIndex    uint   `gorm:"uniqueIndex:txhash_logindex" json:"log_index"`
 	Action      string `json:"action"`
 	ClusterID   string `gorm:"index" json:"cluster_id"`
+	BlockHash   string `gorm:"type:VARCHAR(70)" json:"block_hash"`
+	// Provisional events are above the finalized block and may still be reorged
+	Provisional bool `gorm:"index" json:"provisional"`
 }
 
 func (s *EventInfo) TableName() string {
@@ -89,3 +92,21 @@ func (s *Store) CreateEvent(events []*EventInfo) error {
 	}
 	return err
 }
+
+// GetLatestClusterEvent returns the last applied event of the cluster with one of the actions
+func (s *Store) GetLatestClusterEvent(clusterID string, actions []string) (*EventInfo, error) {
+	var event EventInfo
+	err := s.db.Model(&EventInfo{}).
+		Where("cluster_id = ? AND provisional = ? AND action IN ?", clusterID, false, actions).
+		Order("block_number DESC, log_index DESC").
+		First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// DeleteProvisionalEvents removes the events above the finalized block, they are rebuilt from the current head
+func (s *Store) DeleteProvisionalEvents() error {
+	return s.db.Unscoped().Where("provisional = ?", true).Delete(&EventInfo{}).Error
+}
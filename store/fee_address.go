@@ -3,6 +3,7 @@ package store
 import (
 	"errors"
 	"gorm.io/gorm"
+	"strings"
 )
 
 type FeeAddressInfo struct {
@@ -42,3 +43,60 @@ func (s *Store) GetClusterFeeAddress(owner string) (FeeAddressInfo, error) {
 
 	return feeAddress, nil
 }
+
+// FeeAddressChangeInfo is a fee recipient update of an owner, it keeps the recipient in effect at older blocks
+type FeeAddressChangeInfo struct {
+	gorm.Model
+	Owner       string `gorm:"type:VARCHAR(64); index" json:"owner"`
+	FeeAddress  string `json:"fee_address"`
+	BlockNumber uint64 `gorm:"index" json:"block_number"`
+	TxHash      string `gorm:"type:VARCHAR(70); uniqueIndex:fee_address_txhash_logindex" json:"tx_hash"`
+	LogIndex    uint   `gorm:"uniqueIndex:fee_address_txhash_logindex" json:"log_index"`
+}
+
+func (s *FeeAddressChangeInfo) TableName() string {
+	return "fee_address_change_infos"
+}
+
+func (s *Store) CreateFeeAddressChange(info *FeeAddressChangeInfo) error {
+	err := s.db.Create(info).Error
+	if err == nil {
+		return nil
+	}
+
+	// maybe rescan event
+	if strings.Contains(err.Error(), "Duplicate entry") {
+		return nil
+	}
+	return err
+}
+
+// GetClusterFeeAddressAt returns the fee address of the owner in effect at blockNumber, an update takes effect
+// after its block. It is empty when the owner had not set one yet. Owners without recorded updates, which were
+// scanned before the history was kept, fall back to the current fee address.
+func (s *Store) GetClusterFeeAddressAt(owner string, blockNumber uint64) (string, error) {
+	var changes []FeeAddressChangeInfo
+	err := s.db.Model(&FeeAddressChangeInfo{}).Where("owner = ? AND block_number < ?", owner, blockNumber).
+		Order("block_number DESC, log_index DESC").Limit(1).Find(&changes).Error
+	if err != nil {
+		return "", err
+	}
+	if len(changes) != 0 {
+		return changes[0].FeeAddress, nil
+	}
+
+	var later int64
+	err = s.db.Model(&FeeAddressChangeInfo{}).Where(&FeeAddressChangeInfo{Owner: owner}).Count(&later).Error
+	if err != nil {
+		return "", err
+	}
+	if later != 0 {
+		return "", nil
+	}
+
+	feeAddress, err := s.GetClusterFeeAddress(owner)
+	if err != nil {
+		return "", err
+	}
+	return feeAddress.FeeAddress, nil
+}
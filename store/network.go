@@ -2,7 +2,27 @@ package store
 
 import (
 	"errors"
+	"fmt"
+	"github.com/monitorssv/monitorssv/eth1/utils"
 	"gorm.io/gorm"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	// NetworkParamNetworkFee and NetworkParamOperatorMaximumFee are SSV wei per block
+	NetworkParamNetworkFee         = "networkFee"
+	NetworkParamOperatorMaximumFee = "operatorMaximumFee"
+	// NetworkParamLiquidationThresholdPeriod is in blocks
+	NetworkParamLiquidationThresholdPeriod = "liquidationThresholdPeriod"
+	// NetworkParamMinimumLiquidationCollateral is in SSV wei
+	NetworkParamMinimumLiquidationCollateral = "minimumLiquidationCollateral"
+	// NetworkParamOperatorFeeIncreaseLimit is a percentage with two decimals, 1000 is 10%
+	NetworkParamOperatorFeeIncreaseLimit = "operatorFeeIncreaseLimit"
+	// NetworkParamDeclareOperatorFeePeriod and NetworkParamExecuteOperatorFeePeriod are in seconds
+	NetworkParamDeclareOperatorFeePeriod = "declareOperatorFeePeriod"
+	NetworkParamExecuteOperatorFeePeriod = "executeOperatorFeePeriod"
 )
 
 type NetworkInfo struct {
@@ -40,3 +60,84 @@ func (s *Store) UpdateUpcomingNetworkFee(fee uint64) error {
 	info.UpcomingNetworkFee = fee
 	return s.db.Save(&info).Error
 }
+
+// NetworkParamInfo is a change of a DAO parameter, the values are decimal strings in the unit of the Param.
+// OldValue is empty when the value before the first change could not be read.
+type NetworkParamInfo struct {
+	gorm.Model
+	Param       string `gorm:"index" json:"param"`
+	BlockNumber uint64 `gorm:"index" json:"block_number"`
+	BlockTime   int64  `json:"block_time"`
+	OldValue    string `json:"old_value"`
+	NewValue    string `json:"new_value"`
+	TxHash      string `gorm:"type:VARCHAR(70); uniqueIndex:param_txhash_logindex" json:"tx_hash"`
+	LogIndex    uint   `gorm:"uniqueIndex:param_txhash_logindex" json:"log_index"`
+}
+
+func (s *NetworkParamInfo) TableName() string {
+	return "network_param_infos"
+}
+
+func (s *Store) CreateNetworkParam(info *NetworkParamInfo) error {
+	err := s.db.Create(info).Error
+	if err == nil {
+		return nil
+	}
+
+	// maybe rescan event
+	if strings.Contains(err.Error(), "Duplicate entry") {
+		return nil
+	}
+	return err
+}
+
+// GetNetworkParamHistory returns the changes of all params, or of the given params, oldest first
+func (s *Store) GetNetworkParamHistory(params []string) ([]NetworkParamInfo, error) {
+	var infos []NetworkParamInfo
+	query := s.db.Model(&NetworkParamInfo{})
+	if len(params) != 0 {
+		query = query.Where("param IN ?", params)
+	}
+	err := query.Order("block_number ASC, log_index ASC").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// GetLatestNetworkParam returns the last change of the param, nil if it never changed
+func (s *Store) GetLatestNetworkParam(param string) (*NetworkParamInfo, error) {
+	var infos []NetworkParamInfo
+	err := s.db.Model(&NetworkParamInfo{}).Where("param = ?", param).
+		Order("block_number DESC, log_index DESC").Limit(1).Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, nil
+	}
+	return &infos[0], nil
+}
+
+// FormatNetworkParam formats a value of the param for display, fees are per year
+func FormatNetworkParam(param string, value string) string {
+	v, ok := big.NewInt(0).SetString(value, 10)
+	if !ok {
+		return "unknown"
+	}
+
+	switch param {
+	case NetworkParamNetworkFee, NetworkParamOperatorMaximumFee:
+		fee := big.NewInt(0).Mul(v, big.NewInt(2613400))
+		return fmt.Sprintf("%s ssv/year", utils.ToSSV(fee, "%.2f"))
+	case NetworkParamLiquidationThresholdPeriod:
+		return fmt.Sprintf("%d blocks (%.1f days)", v.Uint64(), float64(v.Uint64())/7200)
+	case NetworkParamMinimumLiquidationCollateral:
+		return fmt.Sprintf("%s ssv", utils.ToSSV(v, "%.2f"))
+	case NetworkParamOperatorFeeIncreaseLimit:
+		return fmt.Sprintf("%.2f%%", float64(v.Uint64())/100)
+	case NetworkParamDeclareOperatorFeePeriod, NetworkParamExecuteOperatorFeePeriod:
+		return (time.Duration(v.Uint64()) * time.Second).String()
+	}
+	return value
+}
@@ -0,0 +1,22 @@
+package store
+
+import "testing"
+
+func TestFormatNetworkParam(t *testing.T) {
+	tests := []struct {
+		param string
+		value string
+		want  string
+	}{
+		{NetworkParamLiquidationThresholdPeriod, "214800", "214800 blocks (29.8 days)"},
+		{NetworkParamOperatorFeeIncreaseLimit, "1000", "10.00%"},
+		{NetworkParamDeclareOperatorFeePeriod, "259200", "72h0m0s"},
+		{NetworkParamMinimumLiquidationCollateral, "1000000000000000000", "1 ssv"},
+		{NetworkParamNetworkFee, "", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := FormatNetworkParam(tt.param, tt.value); got != tt.want {
+			t.Errorf("FormatNetworkParam(%s, %s) = %s, want %s", tt.param, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"gorm.io/gorm"
+)
+
+// ValidatorOfflineInfo is a period in which a validator missed all of its duties, EndEpoch is 0 while it lasts
+type ValidatorOfflineInfo struct {
+	gorm.Model
+	ClusterID      string `gorm:"type:VARCHAR(64); index" json:"cluster_id"`
+	ValidatorIndex uint64 `gorm:"index" json:"validator_index"`
+	StartEpoch     uint64 `json:"start_epoch"`
+	EndEpoch       uint64 `gorm:"index" json:"end_epoch"`
+	// Epochs the validator was seen offline, the epochs the monitor did not process are not included
+	Epochs       uint64 `json:"epochs"`
+	MissedDuties uint64 `json:"missed_duties"`
+}
+
+func (s *ValidatorOfflineInfo) TableName() string {
+	return "validator_offline_infos"
+}
+
+func (s *Store) CreateValidatorOffline(info *ValidatorOfflineInfo) error {
+	return s.db.Create(info).Error
+}
+
+func (s *Store) UpdateValidatorOfflineDuties(validatorIndex, epochs, missedDuties uint64) error {
+	return s.db.Model(&ValidatorOfflineInfo{}).Where("validator_index = ? AND end_epoch = 0", validatorIndex).Updates(map[string]interface{}{
+		"epochs":        epochs,
+		"missed_duties": missedDuties,
+	}).Error
+}
+
+// ValidatorRecovered closes the ongoing offline period of the validator, endEpoch is the first epoch it performed its duties again
+func (s *Store) ValidatorRecovered(validatorIndex, endEpoch, epochs, missedDuties uint64) error {
+	return s.db.Model(&ValidatorOfflineInfo{}).Where("validator_index = ? AND end_epoch = 0", validatorIndex).Updates(map[string]interface{}{
+		"end_epoch":     endEpoch,
+		"epochs":        epochs,
+		"missed_duties": missedDuties,
+	}).Error
+}
+
+func (s *Store) GetOngoingValidatorOfflines() ([]ValidatorOfflineInfo, error) {
+	var infos []ValidatorOfflineInfo
+	err := s.db.Model(&ValidatorOfflineInfo{}).Where("end_epoch = 0").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+func (s *Store) GetValidatorOfflinesByClusterId(page int, itemsPerPage int, clusterID string) ([]ValidatorOfflineInfo, int64, error) {
+	perPage, offset := pagingCheck(page, itemsPerPage)
+	var totalCount int64
+	err := s.db.Model(&ValidatorOfflineInfo{}).Where(&ValidatorOfflineInfo{ClusterID: clusterID}).Count(&totalCount).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var infos []ValidatorOfflineInfo
+	err = s.db.Model(&ValidatorOfflineInfo{}).Where(&ValidatorOfflineInfo{ClusterID: clusterID}).Order("start_epoch DESC").Offset(offset).Limit(perPage).Find(&infos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return infos, totalCount, nil
+}
+
+func (s *Store) GetValidatorOfflinesByIndex(validatorIndex uint64) ([]ValidatorOfflineInfo, error) {
+	var infos []ValidatorOfflineInfo
+	err := s.db.Model(&ValidatorOfflineInfo{}).Where("validator_index = ?", validatorIndex).Order("start_epoch DESC").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
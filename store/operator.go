@@ -26,6 +26,17 @@ type OperatorInfo struct {
 	PendingOperatorFee   string `gorm:"default:0;index" json:"pending_operator_fee"`
 	ApprovalBeginTime    int64  `gorm:"default:0" json:"approval_begin_time"`
 	ApprovalEndTime      int64  `gorm:"default:0" json:"approval_end_time"`
+	// performance scores in percent, 0 without duties in the period
+	Performance24h float64 `gorm:"default:0;index" json:"performance_24h"`
+	Performance7d  float64 `gorm:"default:0;index" json:"performance_7d"`
+	Performance30d float64 `gorm:"default:0;index" json:"performance_30d"`
+	Incidents30d   uint64  `gorm:"default:0" json:"incidents_30d"`
+}
+
+var operatorPerformanceColumns = map[string]string{
+	"24h": "performance_24h",
+	"7d":  "performance_7d",
+	"30d": "performance_30d",
 }
 
 func (s *OperatorInfo) TableName() string {
@@ -101,6 +112,46 @@ func (s *Store) GetOperators(page int, itemsPerPage int) ([]OperatorInfo, int64,
 	return operators, totalCount, nil
 }
 
+// GetOperatorsByPerformance sorts operators by the score of period (24h, 7d or 30d) and skips those below minScore
+func (s *Store) GetOperatorsByPerformance(page int, itemsPerPage int, period string, minScore float64) ([]OperatorInfo, int64, error) {
+	column, ok := operatorPerformanceColumns[period]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown performance period: %s", period)
+	}
+
+	perPage, offset := pagingCheck(page, itemsPerPage)
+	var totalCount int64
+	query := s.db.Model(&OperatorInfo{}).Where("remove_block = 0").Where(column+" >= ?", minScore)
+	err := query.Count(&totalCount).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	var operators []OperatorInfo
+	err = query.Order(column + " DESC, operator_id ASC").Offset(offset).Limit(perPage).Find(&operators).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return operators, totalCount, nil
+}
+
+func (s *Store) GetActiveOperators() ([]OperatorInfo, error) {
+	var operators []OperatorInfo
+	err := s.db.Model(&OperatorInfo{}).Where("remove_block = 0").Find(&operators).Error
+	if err != nil {
+		return nil, err
+	}
+	return operators, nil
+}
+
+func (s *Store) GetAllOperators() ([]OperatorInfo, error) {
+	var operators []OperatorInfo
+	err := s.db.Model(&OperatorInfo{}).Find(&operators).Error
+	if err != nil {
+		return nil, err
+	}
+	return operators, nil
+}
+
 func (s *Store) GetOperatorByOperatorId(operatorId uint64) (*OperatorInfo, error) {
 	var operator OperatorInfo
 	err := s.db.Model(&OperatorInfo{}).Where(&OperatorInfo{OperatorId: operatorId}).First(&operator).Error
@@ -214,6 +265,15 @@ func (s *Store) RemoveOperator(operatorId uint64, removeBlock int64) error {
 	return s.db.Model(&OperatorInfo{}).Where(&OperatorInfo{OperatorId: operatorId}).Update("remove_block", removeBlock).Error
 }
 
+func (s *Store) UpdateOperatorPerformance(operatorId uint64, performance24h, performance7d, performance30d float64, incidents30d uint64) error {
+	return s.db.Model(&OperatorInfo{}).Where("operator_id = ?", operatorId).Updates(map[string]interface{}{
+		"performance_24h": performance24h,
+		"performance_7d":  performance7d,
+		"performance_30d": performance30d,
+		"incidents_30d":   incidents30d,
+	}).Error
+}
+
 func (s *Store) UpdateOperatorValidatorCount(operatorId uint64, count uint32) error {
 	return s.db.Model(&OperatorInfo{}).Where(&OperatorInfo{OperatorId: operatorId}).Update("validator_count", count).Error
 }
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// OperatorFeeInfo is a fee event of an operator, the fees are per block like OperatorInfo.OperatorFee.
+// A declaration has the declared fee as NewFee, a cancellation the cancelled one.
+type OperatorFeeInfo struct {
+	gorm.Model
+	OperatorId  uint64 `gorm:"index" json:"operator_id"`
+	Action      string `json:"action"`
+	BlockNumber uint64 `gorm:"index" json:"block_number"`
+	BlockTime   int64  `json:"block_time"`
+	OldFee      string `json:"old_fee"`
+	NewFee      string `json:"new_fee"`
+	Increase    bool   `json:"increase"`
+	TxHash      string `gorm:"type:VARCHAR(70); uniqueIndex:fee_txhash_logindex" json:"tx_hash"`
+	LogIndex    uint   `gorm:"uniqueIndex:fee_txhash_logindex" json:"log_index"`
+}
+
+func (s *OperatorFeeInfo) TableName() string {
+	return "operator_fee_infos"
+}
+
+func (s *Store) CreateOperatorFee(info *OperatorFeeInfo) error {
+	err := s.db.Create(info).Error
+	if err == nil {
+		return nil
+	}
+
+	// maybe rescan event
+	if strings.Contains(err.Error(), "Duplicate entry") {
+		return nil
+	}
+	return err
+}
+
+func (s *Store) GetOperatorFeeHistory(operatorId uint64) ([]OperatorFeeInfo, error) {
+	var infos []OperatorFeeInfo
+	err := s.db.Model(&OperatorFeeInfo{}).Where("operator_id = ?", operatorId).Order("block_number ASC, log_index ASC").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// GetLatestOperatorFee returns the last fee event of the operator with the action, nil if there is none
+func (s *Store) GetLatestOperatorFee(operatorId uint64, action string) (*OperatorFeeInfo, error) {
+	var infos []OperatorFeeInfo
+	err := s.db.Model(&OperatorFeeInfo{}).Where("operator_id = ? AND action = ?", operatorId, action).
+		Order("block_number DESC, log_index DESC").Limit(1).Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, nil
+	}
+	return &infos[0], nil
+}
+
+// GetOperatorFeeIncreaseCounts counts the executed fee increases of the operators since the unix time
+func (s *Store) GetOperatorFeeIncreaseCounts(operatorIds []uint64, action string, since int64) (map[uint64]int64, error) {
+	var rows []struct {
+		OperatorId uint64
+		Count      int64
+	}
+	err := s.db.Model(&OperatorFeeInfo{}).
+		Select("operator_id, count(*) as count").
+		Where("operator_id IN ? AND action = ? AND increase = ? AND block_time >= ?", operatorIds, action, true, since).
+		Group("operator_id").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint64]int64, len(rows))
+	for _, row := range rows {
+		res[row.OperatorId] = row.Count
+	}
+	return res, nil
+}
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStore_GetOperatorFeeHistory(t *testing.T) {
+	db := initDB(t)
+
+	fees, err := db.GetOperatorFeeHistory(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(fees)
+}
+
+func TestStore_GetOperatorFeeIncreaseCounts(t *testing.T) {
+	db := initDB(t)
+
+	counts, err := db.GetOperatorFeeIncreaseCounts([]uint64{1, 2, 3, 4}, "OperatorFeeExecuted", time.Now().Add(-180*24*time.Hour).Unix())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(counts)
+}
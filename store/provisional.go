@@ -0,0 +1,241 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// ProvisionalRowInfo marks a row changed by a log above the finalized block. RowValues holds the columns of the
+// row before the log as json, empty if the log created the row, so the change can be rolled back after a reorg.
+type ProvisionalRowInfo struct {
+	gorm.Model
+	BlockNumber uint64 `gorm:"index" json:"block_number"`
+	BlockHash   string `gorm:"type:VARCHAR(70)" json:"block_hash"`
+	TxHash      string `gorm:"type:VARCHAR(70)" json:"tx_hash"`
+	LogIndex    uint   `json:"log_index"`
+	RowTable    string `gorm:"type:VARCHAR(64)" json:"row_table"`
+	RowID       uint   `json:"row_id"`
+	RowValues   string `gorm:"type:TEXT" json:"row_values"`
+}
+
+func (s *ProvisionalRowInfo) TableName() string {
+	return "provisional_row_infos"
+}
+
+// RowScope selects the rows of Table a log may change, an empty Where selects all of them
+type RowScope struct {
+	Table string
+	Where string
+	Args  []interface{}
+}
+
+// row is a row by column, the gorm.Model columns are left out
+type row map[string]*string
+
+// RowSnapshot holds the rows of every scope by id
+type RowSnapshot []map[uint]row
+
+// SnapshotRows reads the rows of the scopes, taken before and after a log is applied
+func (s *Store) SnapshotRows(scopes []RowScope) (RowSnapshot, error) {
+	res := make(RowSnapshot, len(scopes))
+	for i, scope := range scopes {
+		rows, err := s.scopeRows(scope)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = rows
+	}
+	return res, nil
+}
+
+func (s *Store) scopeRows(scope RowScope) (map[uint]row, error) {
+	query := s.db.Table(scope.Table).Where("deleted_at IS NULL")
+	if scope.Where != "" {
+		query = query.Where(scope.Where, scope.Args...)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint]row)
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		var id uint
+		r := make(row)
+		for i, column := range columns {
+			switch column {
+			case "id":
+				if _, err = fmt.Sscan(values[i].String, &id); err != nil {
+					return nil, err
+				}
+			case "created_at", "updated_at", "deleted_at":
+			default:
+				if values[i].Valid {
+					value := values[i].String
+					r[column] = &value
+				} else {
+					r[column] = nil
+				}
+			}
+		}
+		res[id] = r
+	}
+	return res, rows.Err()
+}
+
+// CreateProvisionalRows records the rows of the scopes the log changed, before are their rows before the log
+func (s *Store) CreateProvisionalRows(blockNumber uint64, blockHash, txHash string, logIndex uint, scopes []RowScope, before RowSnapshot) (int, error) {
+	after, err := s.SnapshotRows(scopes)
+	if err != nil {
+		return 0, err
+	}
+
+	var infos []*ProvisionalRowInfo
+	for i, scope := range scopes {
+		for id, prev := range changedRows(before[i], after[i]) {
+			info := &ProvisionalRowInfo{
+				BlockNumber: blockNumber,
+				BlockHash:   blockHash,
+				TxHash:      txHash,
+				LogIndex:    logIndex,
+				RowTable:    scope.Table,
+				RowID:       id,
+			}
+			if prev != nil {
+				data, err := json.Marshal(prev)
+				if err != nil {
+					return 0, err
+				}
+				info.RowValues = string(data)
+			}
+			infos = append(infos, info)
+		}
+	}
+	if len(infos) == 0 {
+		return 0, nil
+	}
+	return len(infos), s.db.Create(infos).Error
+}
+
+// changedRows returns the rows of after which differ from before by id, with their row before. The row is nil
+// for the rows created in between.
+func changedRows(before, after map[uint]row) map[uint]row {
+	res := make(map[uint]row)
+	for id, r := range after {
+		prev, ok := before[id]
+		if !ok {
+			res[id] = nil
+			continue
+		}
+		if !equalRows(prev, r) {
+			res[id] = prev
+		}
+	}
+	return res
+}
+
+func equalRows(a, b row) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for column, value := range a {
+		other, ok := b[column]
+		if !ok || (value == nil) != (other == nil) || (value != nil && *value != *other) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetProvisionalBlocks returns the block hash of every block with provisional rows
+func (s *Store) GetProvisionalBlocks() (map[uint64]string, error) {
+	var infos []ProvisionalRowInfo
+	err := s.db.Model(&ProvisionalRowInfo{}).Select("DISTINCT block_number, block_hash").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[uint64]string, len(infos))
+	for _, info := range infos {
+		res[info.BlockNumber] = info.BlockHash
+	}
+	return res, nil
+}
+
+// GetLatestProvisionalBlock returns 0 if there are no provisional rows
+func (s *Store) GetLatestProvisionalBlock() (uint64, error) {
+	var block sql.NullInt64
+	err := s.db.Model(&ProvisionalRowInfo{}).Select("MAX(block_number)").Scan(&block).Error
+	if err != nil {
+		return 0, err
+	}
+	return uint64(block.Int64), nil
+}
+
+// RollbackProvisionalRows restores the rows changed from fromBlock on, the latest change first, and returns the
+// number of restored rows
+func (s *Store) RollbackProvisionalRows(fromBlock uint64) (int, error) {
+	var infos []ProvisionalRowInfo
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ProvisionalRowInfo{}).Where("block_number >= ?", fromBlock).Order("id DESC").Find(&infos).Error
+		if err != nil {
+			return err
+		}
+
+		for _, info := range infos {
+			if info.RowValues == "" {
+				err = tx.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE id = ?", info.RowTable), info.RowID).Error
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			var prev row
+			if err = json.Unmarshal([]byte(info.RowValues), &prev); err != nil {
+				return err
+			}
+			set := make([]string, 0, len(prev))
+			args := make([]interface{}, 0, len(prev)+1)
+			for column, value := range prev {
+				set = append(set, fmt.Sprintf("`%s` = ?", column))
+				if value == nil {
+					args = append(args, nil)
+				} else {
+					args = append(args, *value)
+				}
+			}
+			args = append(args, info.RowID)
+			err = tx.Exec(fmt.Sprintf("UPDATE `%s` SET %s WHERE id = ?", info.RowTable, strings.Join(set, ", ")), args...).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("block_number >= ?", fromBlock).Delete(&ProvisionalRowInfo{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(infos), nil
+}
+
+// ConfirmProvisionalRows drops the provisional marks up to the finalized block toBlock
+func (s *Store) ConfirmProvisionalRows(toBlock uint64) error {
+	return s.db.Unscoped().Where("block_number <= ?", toBlock).Delete(&ProvisionalRowInfo{}).Error
+}
@@ -0,0 +1,29 @@
+package store
+
+import "testing"
+
+func TestChangedRows(t *testing.T) {
+	one, two := "1", "2"
+	before := map[uint]row{
+		1: {"validator_count": &one, "balance": &one},
+		2: {"validator_count": &one, "balance": nil},
+		3: {"validator_count": &two, "balance": &two},
+	}
+	after := map[uint]row{
+		1: {"validator_count": &one, "balance": &one},
+		2: {"validator_count": &one, "balance": &two},
+		3: {"validator_count": &two, "balance": &two},
+		4: {"validator_count": &one, "balance": &one},
+	}
+
+	changed := changedRows(before, after)
+	if len(changed) != 2 {
+		t.Fatalf("expected 2 changed rows, got %v", changed)
+	}
+	if prev, ok := changed[2]; !ok || prev["balance"] != nil {
+		t.Fatalf("expected row 2 with its previous balance, got %v", prev)
+	}
+	if prev, ok := changed[4]; !ok || prev != nil {
+		t.Fatalf("expected created row 4 without a previous row, got %v", prev)
+	}
+}
@@ -0,0 +1,238 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// reindexTable describes a table rebuilt from the SSV events. Columns are written by the event handlers and compared,
+// Carry are maintained by other jobs (beacon monitor, liquidation and operator loops) and taken over from the live rows.
+type reindexTable struct {
+	Name    string
+	Key     []string
+	Columns []string
+	Carry   []string
+	Filter  string
+}
+
+var reindexTables = []reindexTable{
+	{
+		Name:    "cluster_infos",
+		Key:     []string{"cluster_id"},
+		Columns: []string{"owner", "operator_ids", "validator_count", "network_fee_index", "index", "active", "balance"},
+		Carry: []string{"eoa_owner", "burn_fee", "on_chain_balance", "liquidation_block", "calc_liquidation_block",
+			"upcoming_burn_fee", "upcoming_liquidation_block", "upcoming_calc_time", "top_up_amount", "top_up_target_days"},
+	},
+	{
+		Name: "operator_infos",
+		Key:  []string{"operator_id"},
+		Columns: []string{"owner", "pub_key", "validator_count", "cluster_ids", "operator_fee", "privacy_status",
+			"whitelisted_address", "whitelisting_contract", "registration_block", "remove_block"},
+		Carry: []string{"operator_name", "operator_earnings", "pending_operator_fee", "approval_begin_time", "approval_end_time",
+			"performance_24h", "performance_7d", "performance_30d", "incidents_30d"},
+	},
+	{
+		Name:    "validator_infos",
+		Key:     []string{"cluster_id", "public_key", "registration_block"},
+		Columns: []string{"owner", "operator_ids", "remove_block", "exited_block"},
+		Carry: []string{"validator_index", "is_slashed", "is_online", "status", "activation_queue_position", "activation_epoch",
+			"exit_epoch", "withdrawable_epoch", "withdrawn_slot", "withdrawn_block", "withdrawn_amount"},
+	},
+	{
+		Name:    "fee_address_infos",
+		Key:     []string{"owner"},
+		Columns: []string{"fee_address"},
+	},
+	{
+		Name:    "fee_address_change_infos",
+		Key:     []string{"tx_hash", "log_index"},
+		Columns: []string{"owner", "fee_address", "block_number"},
+	},
+	{
+		Name:    "operator_fee_infos",
+		Key:     []string{"tx_hash", "log_index"},
+		Columns: []string{"operator_id", "action", "block_number", "block_time", "old_fee", "new_fee", "increase"},
+	},
+	{
+		Name:    "network_param_infos",
+		Key:     []string{"tx_hash", "log_index"},
+		Columns: []string{"param", "block_number", "block_time", "old_value", "new_value"},
+	},
+	{
+		Name:    "cluster_ledger_infos",
+		Key:     []string{"tx_hash", "log_index"},
+		Columns: []string{"cluster_id", "action", "block_number", "block_time", "amount", "burn", "balance", "validator_count"},
+	},
+	{
+		Name:    "event_infos",
+		Key:     []string{"tx_hash", "log_index"},
+		Columns: []string{"block_number", "owner", "action", "cluster_id"},
+		Filter:  "provisional = false",
+	},
+}
+
+type ColumnChange struct {
+	Column string `json:"column"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+type RowDiff struct {
+	Key     string         `json:"key"`
+	Changes []ColumnChange `json:"changes"`
+}
+
+// TableDiff keys are the key columns joined by "/"
+type TableDiff struct {
+	Table   string    `json:"table"`
+	Added   []string  `json:"added"`
+	Removed []string  `json:"removed"`
+	Changed []RowDiff `json:"changed"`
+}
+
+// RecreateDatabase drops and creates the database name on the server of s
+func (s *Store) RecreateDatabase(name string) error {
+	err := s.db.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", name)).Error
+	if err != nil {
+		return err
+	}
+	return s.db.Exec(fmt.Sprintf("CREATE DATABASE `%s` CHARACTER SET utf8mb4", name)).Error
+}
+
+// DiffReindex compares the event columns of the live rows in s with the rows rebuilt in shadow
+func (s *Store) DiffReindex(shadow *Store) ([]TableDiff, error) {
+	var res []TableDiff
+	for _, table := range reindexTables {
+		live, err := s.reindexRows(table)
+		if err != nil {
+			return nil, err
+		}
+		rebuilt, err := shadow.reindexRows(table)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, diffTableRows(table.Name, table.Columns, live, rebuilt))
+	}
+	return res, nil
+}
+
+func (s *Store) reindexRows(table reindexTable) (map[string]map[string]string, error) {
+	columns := append(append([]string{}, table.Key...), table.Columns...)
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = fmt.Sprintf("`%s`", column)
+	}
+
+	query := s.db.Table(table.Name).Select(strings.Join(quoted, ", ")).Where("deleted_at IS NULL")
+	if table.Filter != "" {
+		query = query.Where(table.Filter)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]map[string]string)
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		key := make([]string, len(table.Key))
+		for i := range table.Key {
+			key[i] = values[i].String
+		}
+		row := make(map[string]string, len(table.Columns))
+		for i, column := range table.Columns {
+			row[column] = values[len(table.Key)+i].String
+		}
+		res[strings.Join(key, "/")] = row
+	}
+	return res, rows.Err()
+}
+
+func diffTableRows(table string, columns []string, live, rebuilt map[string]map[string]string) TableDiff {
+	diff := TableDiff{Table: table}
+	for key, row := range rebuilt {
+		liveRow, ok := live[key]
+		if !ok {
+			diff.Added = append(diff.Added, key)
+			continue
+		}
+
+		var changes []ColumnChange
+		for _, column := range columns {
+			if liveRow[column] != row[column] {
+				changes = append(changes, ColumnChange{Column: column, Old: liveRow[column], New: row[column]})
+			}
+		}
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, RowDiff{Key: key, Changes: changes})
+		}
+	}
+	for key := range live {
+		if _, ok := rebuilt[key]; !ok {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return diff.Changed[i].Key < diff.Changed[j].Key
+	})
+	return diff
+}
+
+// SwapReindex moves the rebuilt tables of shadowDB in place of the live ones, which are kept in backupDB.
+// The carried columns are copied from the live rows first, so only the event columns change.
+func (s *Store) SwapReindex(shadowDB, backupDB string) error {
+	var liveDB string
+	err := s.db.Raw("SELECT DATABASE()").Scan(&liveDB).Error
+	if err != nil {
+		return err
+	}
+	err = s.db.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s` CHARACTER SET utf8mb4", backupDB)).Error
+	if err != nil {
+		return err
+	}
+
+	var renames []string
+	for _, table := range reindexTables {
+		if len(table.Carry) > 0 {
+			on := make([]string, len(table.Key))
+			for i, key := range table.Key {
+				on[i] = fmt.Sprintf("r.`%s` = l.`%s`", key, key)
+			}
+			set := make([]string, len(table.Carry))
+			for i, column := range table.Carry {
+				set[i] = fmt.Sprintf("r.`%s` = l.`%s`", column, column)
+			}
+			err = s.db.Exec(fmt.Sprintf("UPDATE `%s`.`%s` r JOIN `%s`.`%s` l ON %s SET %s",
+				shadowDB, table.Name, liveDB, table.Name, strings.Join(on, " AND "), strings.Join(set, ", "))).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		renames = append(renames,
+			fmt.Sprintf("`%s`.`%s` TO `%s`.`%s`", liveDB, table.Name, backupDB, table.Name),
+			fmt.Sprintf("`%s`.`%s` TO `%s`.`%s`", shadowDB, table.Name, liveDB, table.Name))
+	}
+
+	// a single RENAME TABLE statement swaps all tables atomically
+	err = s.db.Exec("RENAME TABLE " + strings.Join(renames, ", ")).Error
+	if err != nil {
+		return err
+	}
+	// the provisional rows refer to the replaced rows, the rebuilt tables stop at the finalized block
+	return s.db.Unscoped().Where("1 = 1").Delete(&ProvisionalRowInfo{}).Error
+}
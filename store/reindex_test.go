@@ -0,0 +1,32 @@
+package store
+
+import "testing"
+
+func TestDiffTableRows(t *testing.T) {
+	columns := []string{"validator_count", "balance"}
+	live := map[string]map[string]string{
+		"a": {"validator_count": "4", "balance": "100"},
+		"b": {"validator_count": "1", "balance": "50"},
+		"c": {"validator_count": "2", "balance": "10"},
+	}
+	rebuilt := map[string]map[string]string{
+		"a": {"validator_count": "4", "balance": "100"},
+		"b": {"validator_count": "2", "balance": "50"},
+		"d": {"validator_count": "1", "balance": "0"},
+	}
+
+	diff := diffTableRows("cluster_infos", columns, live, rebuilt)
+	if len(diff.Added) != 1 || diff.Added[0] != "d" {
+		t.Fatalf("expected d added, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "c" {
+		t.Fatalf("expected c removed, got %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Key != "b" {
+		t.Fatalf("expected b changed, got %v", diff.Changed)
+	}
+	change := diff.Changed[0].Changes
+	if len(change) != 1 || change[0].Column != "validator_count" || change[0].Old != "1" || change[0].New != "2" {
+		t.Fatalf("unexpected changes %+v", change)
+	}
+}
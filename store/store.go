@@ -52,6 +52,10 @@ func NewStore(cfg *config.Config) (*Store, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = db.AutoMigrate(&FeeAddressChangeInfo{})
+	if err != nil {
+		return nil, err
+	}
 	err = db.AutoMigrate(&EventInfo{})
 	if err != nil {
 		return nil, err
@@ -76,6 +80,34 @@ func NewStore(cfg *config.Config) (*Store, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = db.AutoMigrate(&ClusterDutyInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&ValidatorOfflineInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&ValidatorStatusInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&OperatorFeeInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&NetworkParamInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&ClusterLedgerInfo{})
+	if err != nil {
+		return nil, err
+	}
+	err = db.AutoMigrate(&ProvisionalRowInfo{})
+	if err != nil {
+		return nil, err
+	}
 
 	return &Store{db: db}, nil
 }
@@ -58,6 +58,16 @@ type ValidatorInfo struct {
 	IsSlashed         bool   `gorm:"default:false" json:"is_slashed"`
 	IsOnline          bool   `gorm:"default:true" json:"is_online"`
 	Status            string `json:"status"`
+	// ActivationEpoch is an estimate while the validator is pending or in the deposit queue, 0 if unknown.
+	// ActivationQueuePosition is the position in the deposit queue, 0 once the validator is in the beacon state.
+	ActivationQueuePosition uint64 `json:"activation_queue_position"`
+	ActivationEpoch         uint64 `json:"activation_epoch"`
+	ExitEpoch               uint64 `json:"exit_epoch"`
+	WithdrawableEpoch       uint64 `json:"withdrawable_epoch"`
+	// WithdrawnSlot is set once the full withdrawal was swept, WithdrawnBlock is 0 when the sweep happened before it was tracked
+	WithdrawnSlot   uint64 `gorm:"index" json:"withdrawn_slot"`
+	WithdrawnBlock  uint64 `json:"withdrawn_block"`
+	WithdrawnAmount uint64 `json:"withdrawn_amount"`
 }
 
 func (s *ValidatorInfo) TableName() string {
@@ -138,15 +148,39 @@ func (s *Store) GetValidatorByPublicKey(publicKey string) (*ValidatorInfo, error
 	return &validator, nil
 }
 
+// GetValidatorRegistrations returns every registration of the public key including the removed ones, oldest first
+func (s *Store) GetValidatorRegistrations(publicKey string) ([]ValidatorInfo, error) {
+	var validators []ValidatorInfo
+	err := s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Order("registration_block ASC").Find(&validators).Error
+	if err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
+
+// GetValidatorByValidatorIndex returns the latest registration of the validator, removed ones included
 func (s *Store) GetValidatorByValidatorIndex(validatorIndex int64) (*ValidatorInfo, error) {
 	var validator ValidatorInfo
-	err := s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{ValidatorIndex: validatorIndex}).First(&validator).Error
+	err := s.db.Model(&ValidatorInfo{}).Where("validator_index = ?", validatorIndex).Order("registration_block DESC").First(&validator).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
 	if err != nil {
 		return nil, err
 	}
 	return &validator, nil
 }
 
+func (s *Store) GetValidatorsByValidatorIndices(validatorIndices []uint64) ([]ValidatorInfo, error) {
+	var validators []ValidatorInfo
+	err := s.db.Model(&ValidatorInfo{}).Where("validator_index IN ? AND remove_block = 0", validatorIndices).Find(&validators).Error
+	if err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
+
 func (s *Store) GetValidatorByPubKeyAndBlock(publicKey string, block uint64) (*ValidatorInfo, error) {
 	var validators []ValidatorInfo
 	query := s.db.Model(&ValidatorInfo{}).Where("public_key = ? AND registration_block < ?", publicKey, block)
@@ -318,8 +352,32 @@ func (s *Store) ExitValidator(publicKey string, exitBlock int64) error {
 	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Update("exited_block", exitBlock).Error
 }
 
-func (s *Store) ValidatorSlash(publicKey string) error {
-	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Update("is_slashed", true).Error
+func (s *Store) UpdateValidatorActivation(publicKey string, queuePosition, activationEpoch uint64) error {
+	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Updates(map[string]interface{}{
+		"activation_queue_position": queuePosition,
+		"activation_epoch":          activationEpoch,
+	}).Error
+}
+
+func (s *Store) UpdateValidatorExitEpoch(publicKey string, exitEpoch, withdrawableEpoch uint64) error {
+	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Updates(map[string]interface{}{
+		"exit_epoch":         exitEpoch,
+		"withdrawable_epoch": withdrawableEpoch,
+	}).Error
+}
+
+func (s *Store) ValidatorWithdrawn(publicKey string, slot, blockNumber, amount uint64) error {
+	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Updates(map[string]interface{}{
+		"withdrawn_slot":   slot,
+		"withdrawn_block":  blockNumber,
+		"withdrawn_amount": amount,
+	}).Error
+}
+
+// ValidatorSlash reports whether the validator was not marked slashed before, the caller that marks it sends the alert
+func (s *Store) ValidatorSlash(publicKey string) (bool, error) {
+	res := s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0 AND is_slashed = ?", false).Update("is_slashed", true)
+	return res.RowsAffected > 0, res.Error
 }
 
 func (s *Store) UpdateValidatorOnlineStatus(validatorIndex int64, status bool) error {
@@ -333,3 +391,92 @@ func (s *Store) UpdateValidatorOnlineStatusByPubKey(pubKey string, status bool)
 func (s *Store) UpdateValidatorIndex(publicKey string, index int64) error {
 	return s.db.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0 AND validator_index = -1").Update("validator_index", index).Error
 }
+
+// validatorBatchSize is the number of rows updated in one transaction
+const validatorBatchSize = 500
+
+// ValidatorKey is the part of ValidatorInfo needed to resolve validators against the beacon state
+type ValidatorKey struct {
+	PublicKey      string
+	ValidatorIndex int64
+	Status         string
+}
+
+func (s *Store) GetValidatorKeys() ([]ValidatorKey, error) {
+	var keys []ValidatorKey
+	err := s.db.Model(&ValidatorInfo{}).Select("public_key, validator_index, status").Where("remove_block = 0").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// BatchUpdateValidatorIndex is UpdateValidatorIndex for many validators, indices by public key
+func (s *Store) BatchUpdateValidatorIndex(indices map[string]int64) error {
+	return batchUpdate(s.db, indices, func(tx *gorm.DB, publicKey string, index int64) error {
+		return tx.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0 AND validator_index = -1").Update("validator_index", index).Error
+	})
+}
+
+// BatchUpdateValidatorStatus is UpdateValidatorStatus for many validators, statuses by public key.
+// Every change is recorded in the status history at epoch.
+func (s *Store) BatchUpdateValidatorStatus(epoch uint64, statuses map[string]string) error {
+	return batchUpdate(s.db, statuses, func(tx *gorm.DB, publicKey string, status string) error {
+		err := tx.Model(&ValidatorInfo{}).Where(&ValidatorInfo{PublicKey: publicKey}).Where("remove_block = 0").Update("status", status).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&ValidatorStatusInfo{PublicKey: publicKey, Epoch: epoch, Status: status}).Error
+	})
+}
+
+// MarkValidatorsUnknown sets validators which are not in the beacon state to unknown and offline
+func (s *Store) MarkValidatorsUnknown(publicKeys []string) error {
+	for i := 0; i < len(publicKeys); i += validatorBatchSize {
+		chunk := publicKeys[i:min(i+validatorBatchSize, len(publicKeys))]
+		err := s.db.Model(&ValidatorInfo{}).Where("public_key IN ? AND remove_block = 0", chunk).Updates(map[string]interface{}{
+			"status":    ValidatorUnknown,
+			"is_online": false,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkValidatorsOnline sets the validators which performed their duties back to online, e.g. the ones marked
+// offline while they were not in the beacon state yet
+func (s *Store) MarkValidatorsOnline(validatorIndices []uint64) error {
+	for i := 0; i < len(validatorIndices); i += validatorBatchSize {
+		chunk := validatorIndices[i:min(i+validatorBatchSize, len(validatorIndices))]
+		err := s.db.Model(&ValidatorInfo{}).Where("validator_index IN ? AND remove_block = 0 AND is_online = ?", chunk, false).Update("is_online", true).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func batchUpdate[V any](db *gorm.DB, updates map[string]V, update func(tx *gorm.DB, publicKey string, value V) error) error {
+	publicKeys := make([]string, 0, len(updates))
+	for publicKey := range updates {
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	for i := 0; i < len(publicKeys); i += validatorBatchSize {
+		chunk := publicKeys[i:min(i+validatorBatchSize, len(publicKeys))]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for _, publicKey := range chunk {
+				if err := update(tx, publicKey, updates[publicKey]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package store
+
+import "gorm.io/gorm"
+
+// ValidatorStatusInfo is a beacon status change of a validator, observed at Epoch
+type ValidatorStatusInfo struct {
+	gorm.Model
+	PublicKey string `gorm:"type:VARCHAR(255); index" json:"public_key"`
+	Epoch     uint64 `json:"epoch"`
+	Status    string `json:"status"`
+}
+
+func (s *ValidatorStatusInfo) TableName() string {
+	return "validator_status_infos"
+}
+
+func (s *Store) GetValidatorStatusHistory(publicKey string) ([]ValidatorStatusInfo, error) {
+	var infos []ValidatorStatusInfo
+	err := s.db.Model(&ValidatorStatusInfo{}).Where(&ValidatorStatusInfo{PublicKey: publicKey}).Order("epoch ASC").Find(&infos).Error
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
@@ -41,6 +41,36 @@ func TestGetValidatorByPublicKey(t *testing.T) {
 	t.Log(validatorInfo)
 }
 
+func TestGetValidatorByValidatorIndex(t *testing.T) {
+	db := initDB(t)
+	pubKey := "849d44839ba6dcb18d351c3a9e3c66cabef2f7132c7854785b3f14472c1f8cfe4e7337fe6cd7edf254acc7025ffd8f2a"
+	registrations, err := db.GetValidatorRegistrations(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(registrations) == 0 || registrations[len(registrations)-1].ValidatorIndex == DefaultValidatorIndex {
+		t.Skip("validator not indexed")
+	}
+
+	// the index resolves to the same validator as the public key, removed registrations included
+	latest := registrations[len(registrations)-1]
+	validatorInfo, err := db.GetValidatorByValidatorIndex(latest.ValidatorIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validatorInfo == nil || validatorInfo.PublicKey != pubKey || validatorInfo.RemoveBlock != latest.RemoveBlock {
+		t.Fatalf("expected the latest registration of %s, got %+v", pubKey, validatorInfo)
+	}
+
+	validatorInfo, err = db.GetValidatorByValidatorIndex(1 << 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validatorInfo != nil {
+		t.Fatalf("expected no validator, got %+v", validatorInfo)
+	}
+}
+
 func TestGetValidatorByClusterId(t *testing.T) {
 	db := initDB(t)
 	validators, totalCount, err := db.GetValidatorByClusterId(1, 10, "1853c5e50b539d5c944e6db8cd54ff839f3bb756ecd39f41a4cc72f7400054dd")